@@ -19,15 +19,22 @@ type fileTab struct {
 type defyne struct {
 	win         fyne.Window
 	projectRoot fyne.URI
-	fileTabs    *container.DocTabs
 	fileTree    *xWidget.FileTree
 	openEditors map[*container.TabItem]*fileTab
+
+	// fileTabs holds the open editors, sideTabs holds those moved to the right of a split editor area
+	fileTabs, sideTabs *container.DocTabs
+	// activeTabs is the side of the editor area that a tab was last selected in, menu actions apply to its editor
+	activeTabs  *container.DocTabs
+	editorPanel *fyne.Container
 }
 
 func (d *defyne) openEditor(u fyne.URI) {
 	for tab, item := range d.openEditors {
 		if item.uri.String() == u.String() {
-			d.fileTabs.Select(tab)
+			tabs := d.tabsHolding(tab)
+			tabs.Select(tab)
+			d.activeTabs = tabs
 			return
 		}
 	}
@@ -50,7 +57,108 @@ func (d *defyne) openEditor(u fyne.URI) {
 
 	newTab := container.NewTabItemWithIcon(u.Name(), theme.FileTextIcon(), ed.content())
 	d.openEditors[newTab] = &fileTab{ed, u}
+	ed.setOnChanged(func() {
+		d.updateTabTitle(newTab)
+	})
+	if s, ok := ed.(styler); ok {
+		s.setOnStylesChanged(func() {
+			d.updateStyles(ed)
+		})
+	}
 
-	d.fileTabs.Append(newTab)
-	d.fileTabs.Select(newTab)
+	d.activeTabs.Append(newTab)
+	d.activeTabs.Select(newTab)
+}
+
+// selectedEditor returns the editor of the tab that is selected in the active side of the editor area.
+func (d *defyne) selectedEditor() (*fileTab, bool) {
+	ed, ok := d.openEditors[d.activeTabs.Selected()]
+	return ed, ok
+}
+
+// tabsHolding returns the side of the editor area that contains the tab.
+func (d *defyne) tabsHolding(t *container.TabItem) *container.DocTabs {
+	for _, item := range d.sideTabs.Items {
+		if item == t {
+			return d.sideTabs
+		}
+	}
+	return d.fileTabs
+}
+
+// moveToOtherSide moves the selected editor to the other side of the editor area, which is split
+// side by side while both sides hold an editor.
+func (d *defyne) moveToOtherSide() {
+	t := d.activeTabs.Selected()
+	if _, ok := d.openEditors[t]; !ok {
+		return
+	}
+
+	from, to := d.fileTabs, d.sideTabs
+	if d.activeTabs == d.sideTabs {
+		from, to = d.sideTabs, d.fileTabs
+	}
+	from.Remove(t)
+	to.Append(t)
+	to.Select(t)
+	d.activeTabs = to
+	d.layoutEditors()
+}
+
+// layoutEditors splits the editor area if any editors have been moved to the side, or shows a single set of tabs.
+func (d *defyne) layoutEditors() {
+	if len(d.sideTabs.Items) == 0 {
+		d.activeTabs = d.fileTabs
+		d.editorPanel.Objects = []fyne.CanvasObject{d.fileTabs}
+	} else {
+		d.editorPanel.Objects = []fyne.CanvasObject{container.NewHSplit(d.fileTabs, d.sideTabs)}
+	}
+	d.editorPanel.Refresh()
+}
+
+// closeTab closes the editor of a tab, and collapses the split editor area if its side is now empty.
+func (d *defyne) closeTab(t *container.TabItem) {
+	ed := d.openEditors[t]
+	ed.close()
+	d.tabsHolding(t).Remove(t)
+	delete(d.openEditors, t)
+	d.layoutEditors()
+}
+
+// updateTabTitle marks the tab of an editor that has unsaved changes.
+func (d *defyne) updateTabTitle(t *container.TabItem) {
+	ed, ok := d.openEditors[t]
+	if !ok {
+		return
+	}
+
+	title := ed.uri.Name()
+	if ed.changed() {
+		title = "*" + title
+	}
+	if t.Text == title {
+		return
+	}
+
+	t.Text = title
+	d.tabsHolding(t).Refresh()
+}
+
+// hasUnsaved returns true if any open editor has changes that are not saved.
+func (d *defyne) hasUnsaved() bool {
+	for _, ed := range d.openEditors {
+		if ed.changed() {
+			return true
+		}
+	}
+	return false
+}
+
+// updateStyles applies the project styles, after they were changed in one editor, to every other open editor.
+func (d *defyne) updateStyles(from editor) {
+	for _, item := range d.openEditors {
+		if s, ok := item.editor.(styler); ok && item.editor != from {
+			s.updateStyles()
+		}
+	}
 }
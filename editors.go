@@ -3,8 +3,9 @@ package main
 import "fyne.io/fyne/v2"
 
 var editorsByFilename = map[string]func(fyne.URI, fyne.Window) editor{
-	".gui.json": newGuiEditor,
-	"go.mod":    newTextEditor,
+	".gui.json":  newGuiEditor,
+	".menu.json": newMenuEditor,
+	"go.mod":     newTextEditor,
 }
 
 var editorsByMime = map[string]func(fyne.URI, fyne.Window) editor{
@@ -23,4 +24,32 @@ type editor interface {
 	content() fyne.CanvasObject
 	run()
 	save()
+	// setOnChanged registers a function to call when the result of changed() may be different.
+	setOnChanged(func())
+}
+
+// clipboarder is implemented by editors that can move their content through the clipboard.
+type clipboarder interface {
+	copy()
+	cut()
+	duplicate()
+	paste()
+}
+
+// compiler is implemented by editors that can build and run their content as a standalone app.
+type compiler interface {
+	runCompiled()
+}
+
+// styler is implemented by editors whose content uses the styles of the project.
+type styler interface {
+	// setOnStylesChanged registers a function to call when this editor changes the project styles.
+	setOnStylesChanged(func())
+	updateStyles()
+}
+
+// undoer is implemented by editors that keep a history of changes.
+type undoer interface {
+	undo()
+	redo()
 }
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+// exportContext provides the information needed to load a design outside of the GUI builder.
+type exportContext struct {
+	meta map[fyne.CanvasObject]map[string]string
+	root fyne.URI
+	th   fyne.Theme
+}
+
+func (e *exportContext) Metadata() map[fyne.CanvasObject]map[string]string {
+	return e.meta
+}
+
+func (e *exportContext) ProjectRoot() fyne.URI {
+	return e.root
+}
+
+func (e *exportContext) Theme() fyne.Theme {
+	return e.th
+}
+
+// runExport implements the "export" command, which renders designs to PNG or SVG images without a display.
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: defyne export [options] file.gui.json...")
+		flags.PrintDefaults()
+	}
+	size := flags.String("size", "", "the size of the image before scaling, such as 360x640 (default is the minimum size of the design)")
+	scale := flags.Float64("scale", 1, "the number of pixels per unit of size")
+	variant := flags.String("variant", "light", "the theme variant to use, light or dark")
+	themeFile := flags.String("theme", "", "a Fyne JSON theme file to draw with")
+	out := flags.String("o", "", "the image file to write, ending .png or .svg, an SVG file embeds the PNG image "+
+		"(default is the design name with .png)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errors.New("no design files specified")
+	}
+	if *out != "" && flags.NArg() > 1 {
+		return errors.New("the -o option can only be used when exporting a single design")
+	}
+
+	test.NewApp()
+	opts := gui.ImageOptions{Scale: float32(*scale)}
+	if *size != "" {
+		var w, h float32
+		if _, err := fmt.Sscanf(*size, "%fx%f", &w, &h); err != nil {
+			return errors.New("invalid size " + *size + ", expected WIDTHxHEIGHT")
+		}
+		opts.Size = fyne.NewSize(w, h)
+	}
+	switch strings.ToLower(*variant) {
+	case "light":
+		opts.Variant = theme.VariantLight
+	case "dark":
+		opts.Variant = theme.VariantDark
+	default:
+		return errors.New("unknown theme variant " + *variant)
+	}
+	if *themeFile != "" {
+		data, err := os.ReadFile(*themeFile)
+		if err != nil {
+			return err
+		}
+		opts.Theme, err = theme.FromJSON(string(data))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, in := range flags.Args() {
+		dest := *out
+		if dest == "" {
+			dest = strings.TrimSuffix(in, ".gui.json") + ".png"
+		}
+
+		if err := exportImage(in, dest, opts); err != nil {
+			return fmt.Errorf("failed to export %s: %w", in, err)
+		}
+	}
+	return nil
+}
+
+func exportImage(in, out string, opts gui.ImageOptions) error {
+	path, err := filepath.Abs(in)
+	if err != nil {
+		return err
+	}
+	ctx := &exportContext{meta: make(map[fyne.CanvasObject]map[string]string), root: moduleRoot(path),
+		th: opts.RenderTheme()}
+	guidefs.LoadProjectLayouts(ctx)
+	guidefs.LoadProjectStyles(ctx)
+	guidefs.LoadProjectStrings(ctx)
+
+	r, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	obj, _, err := gui.DecodeObject(r, ctx)
+	_ = r.Close()
+	if err != nil {
+		return err
+	}
+	if obj == nil {
+		return errors.New("the file does not contain a design")
+	}
+
+	w, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(filepath.Ext(out), ".svg") {
+		err = gui.ExportSVG(obj, opts, w)
+	} else {
+		err = gui.ExportPNG(obj, opts, w)
+	}
+	if err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// moduleRoot returns the directory containing the go.mod file for a design, or the design's directory if none is found.
+func moduleRoot(path string) fyne.URI {
+	dir := filepath.Dir(path)
+	for parent := dir; ; parent = filepath.Dir(parent) {
+		if _, err := os.Stat(filepath.Join(parent, "go.mod")); err == nil {
+			return storage.NewFileURI(parent)
+		}
+		if filepath.Dir(parent) == parent {
+			break
+		}
+	}
+	return storage.NewFileURI(dir)
+}
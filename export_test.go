@@ -0,0 +1,44 @@
+package main
+
+import (
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/fyne-io/defyne/pkg/gui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunExport(t *testing.T) {
+	dir := t.TempDir()
+	design := filepath.Join(dir, "main.gui.json")
+	r := canvas.NewRectangle(color.Black)
+	ctx := &exportContext{meta: map[fyne.CanvasObject]map[string]string{
+		r: {"color.FillColor": string(theme.ColorNamePrimary)}}}
+	f, err := os.Create(design)
+	require.Nil(t, err)
+	require.Nil(t, gui.EncodeObject(r, ctx, f))
+	require.Nil(t, f.Close())
+
+	out := filepath.Join(dir, "out.png")
+	require.Nil(t, runExport([]string{"-size", "20x10", "-scale", "2", "-variant", "dark", "-o", out, design}))
+
+	f, err = os.Open(out)
+	require.Nil(t, err)
+	defer f.Close()
+	img, err := png.Decode(f)
+	require.Nil(t, err)
+	assert.Equal(t, 40, img.Bounds().Dx())
+	assert.Equal(t, 20, img.Bounds().Dy())
+	assert.Equal(t, color.NRGBAModel.Convert(theme.DefaultTheme().Color(theme.ColorNamePrimary, theme.VariantDark)),
+		color.NRGBAModel.Convert(img.At(20, 10)))
+
+	assert.NotNil(t, runExport([]string{"-variant", "dim", design}))
+}
@@ -7,23 +7,33 @@ import (
 	"github.com/fyne-io/defyne/internal/guibuilder"
 )
 
-// Declare conformity with editor interface
+// Declare conformity with editor, clipboarder, undoer, compiler and styler interfaces
 var _ editor = (*guiEditor)(nil)
+var _ clipboarder = (*guiEditor)(nil)
+var _ undoer = (*guiEditor)(nil)
+var _ compiler = (*guiEditor)(nil)
+var _ styler = (*guiEditor)(nil)
 
 type guiEditor struct {
-	uri     fyne.URI
-	builder *guibuilder.Builder
-	edited  bool
-	win     fyne.Window
+	uri       fyne.URI
+	builder   *guibuilder.Builder
+	win       fyne.Window
+	onChanged func()
 }
 
 func newGuiEditor(u fyne.URI, win fyne.Window) editor {
 	builder := guibuilder.NewBuilder(u, win)
-	return &guiEditor{uri: u, builder: builder, win: win}
+	g := &guiEditor{uri: u, builder: builder, win: win}
+	builder.OnChanged = g.notifyChanged
+	return g
 }
 
 func (g *guiEditor) changed() bool {
-	return g.edited
+	return g.builder.Changed()
+}
+
+func (g *guiEditor) copy() {
+	g.builder.Copy()
 }
 
 func (g *guiEditor) content() fyne.CanvasObject {
@@ -33,10 +43,36 @@ func (g *guiEditor) content() fyne.CanvasObject {
 func (g *guiEditor) close() {
 }
 
+func (g *guiEditor) cut() {
+	g.builder.Cut()
+}
+
+func (g *guiEditor) duplicate() {
+	g.builder.Duplicate()
+}
+
+func (g *guiEditor) notifyChanged() {
+	if g.onChanged != nil {
+		g.onChanged()
+	}
+}
+
+func (g *guiEditor) paste() {
+	g.builder.Paste()
+}
+
+func (g *guiEditor) redo() {
+	g.builder.Redo()
+}
+
 func (g *guiEditor) run() {
 	g.builder.Run()
 }
 
+func (g *guiEditor) runCompiled() {
+	g.builder.RunCompiled()
+}
+
 func (g *guiEditor) save() {
 	err := g.builder.Save()
 	if err != nil {
@@ -44,5 +80,21 @@ func (g *guiEditor) save() {
 		return
 	}
 
-	g.edited = false
+	g.notifyChanged()
+}
+
+func (g *guiEditor) setOnChanged(fn func()) {
+	g.onChanged = fn
+}
+
+func (g *guiEditor) setOnStylesChanged(fn func()) {
+	g.builder.OnStylesChanged = fn
+}
+
+func (g *guiEditor) undo() {
+	g.builder.Undo()
+}
+
+func (g *guiEditor) updateStyles() {
+	g.builder.UpdateStyles()
 }
@@ -37,3 +37,6 @@ func (i *imageEditor) run() {
 
 func (i *imageEditor) save() {
 }
+
+func (i *imageEditor) setOnChanged(func()) {
+}
@@ -0,0 +1,209 @@
+package guibuilder
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+// Copy places the selected object, and everything it contains, on the clipboard.
+func (b *Builder) Copy() {
+	data := b.encodeObject(b.current)
+	if data == nil {
+		return
+	}
+
+	fyne.CurrentApp().Clipboard().SetContent(string(data))
+}
+
+// Cut copies the selected object to the clipboard and removes it from the design.
+func (b *Builder) Cut() {
+	if b.current == nil || parentOf(b.root, b.current) == nil {
+		return // cannot cut the root
+	}
+
+	b.Copy()
+	b.remove(b.current)
+}
+
+// Paste inserts the object from the clipboard into the selected container,
+// or after the selected object if it is not a container.
+func (b *Builder) Paste() {
+	obj, err := b.decodeObject([]byte(fyne.CurrentApp().Clipboard().Content()))
+	if err != nil {
+		dialog.ShowError(err, b.win)
+		return
+	}
+
+	parent, index := b.pasteTarget()
+	if parent == nil {
+		dialog.ShowInformation("Cannot paste", "Please select a container to paste into", b.win)
+		return
+	}
+
+	b.insert(parent, index, "", obj)
+	b.choose(obj)
+	b.recordChange(structureChange, parent)
+}
+
+// Duplicate adds a copy of the selected object immediately after it.
+func (b *Builder) Duplicate() {
+	parent := parentOf(b.root, b.current)
+	if parent == nil || !canAdd(parent) {
+		return
+	}
+
+	obj, err := b.decodeObject(b.encodeObject(b.current))
+	if err != nil {
+		fyne.LogError("Failed to duplicate object", err)
+		return
+	}
+
+	index := -1
+	if _, ok := parent.(*fyne.Container); ok {
+		index = indexIn(parent, b.current) + 1
+	}
+	b.insert(parent, index, "", obj)
+	b.choose(obj)
+	b.recordChange(structureChange, parent)
+}
+
+func (b *Builder) encodeObject(o fyne.CanvasObject) []byte {
+	if o == nil {
+		return nil
+	}
+
+	tree, err := gui.EncodeMap(o, b)
+	if err != nil {
+		fyne.LogError("Failed to encode object", err)
+		return nil
+	}
+	data, err := json.MarshalIndent(tree, "", "  ")
+	if err != nil {
+		fyne.LogError("Failed to encode object", err)
+		return nil
+	}
+	return data
+}
+
+// decodeObject creates a new object tree from the data, renaming variables that are already used in the design.
+func (b *Builder) decodeObject(data []byte) (fyne.CanvasObject, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil || m["Type"] == nil {
+		return nil, errors.New("the clipboard does not contain a design object")
+	}
+
+	used := make(map[string]bool)
+	walk(b.root, func(o fyne.CanvasObject) {
+		for _, key := range nameKeys(o) {
+			if name := b.meta[o][key]; name != "" {
+				used[name] = true
+			}
+		}
+	})
+
+	obj, err := gui.DecodeMap(m, b)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, errors.New("unable to decode object of type " + m["Type"].(string))
+	}
+
+	walk(obj, func(o fyne.CanvasObject) {
+		props := b.meta[o]
+		for _, key := range nameKeys(o) {
+			if props[key] == "" {
+				continue
+			}
+
+			props[key] = uniqueName(props[key], used)
+			used[props[key]] = true
+		}
+	})
+	return obj, nil
+}
+
+// nameKeys returns the metadata keys that hold variable names of an object, including those of toolbar items.
+func nameKeys(o fyne.CanvasObject) []string {
+	keys := []string{"name"}
+	if bar, ok := o.(*widget.Toolbar); ok {
+		for i := range bar.Items {
+			keys = append(keys, guidefs.ToolbarItemKey(i, guidefs.ToolbarItemName))
+		}
+	}
+	return keys
+}
+
+// pasteTarget returns the container and index that pasted objects should be inserted at.
+func (b *Builder) pasteTarget() (fyne.CanvasObject, int) {
+	if b.current == nil {
+		return b.root, -1
+	}
+	if isContainer(b.current) && canAdd(b.current) {
+		return b.current, -1
+	}
+
+	parent := parentOf(b.root, b.current)
+	if parent == nil || !canAdd(parent) {
+		return nil, -1
+	}
+	if _, ok := parent.(*fyne.Container); ok {
+		return parent, indexIn(parent, b.current) + 1
+	}
+	return parent, -1
+}
+
+// walk calls fn for o and every object it contains.
+func walk(o fyne.CanvasObject, fn func(fyne.CanvasObject)) {
+	if o == nil {
+		return
+	}
+
+	fn(o)
+	for _, child := range children(o) {
+		walk(child, fn)
+	}
+}
+
+// canAdd returns true if another child can be added to the container without replacing one.
+func canAdd(parent fyne.CanvasObject) bool {
+	switch parent.(type) {
+	case *fyne.Container, *container.AppTabs:
+		return true
+	}
+
+	for _, child := range children(parent) {
+		if child == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueName returns name if it is not used, otherwise the name with the lowest number suffix that is free.
+func uniqueName(name string, used map[string]bool) string {
+	if !used[name] {
+		return name
+	}
+
+	base := strings.TrimRight(name, "0123456789")
+	if base == "" {
+		base = name
+	}
+	for i := 2; ; i++ {
+		next := base + strconv.Itoa(i)
+		if !used[next] {
+			return next
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package guibuilder
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const toolbarDesign = `{"Type": "*fyne.Container", "Layout": "VBox", "Objects": [
+	{"Type": "*widget.Label", "Name": "title", "Struct": {"Text": "Hello"}},
+	{"Type": "*widget.Label", "Name": "title2", "Struct": {"Text": "Again"}},
+	{"Type": "*widget.Toolbar", "Name": "bar", "Struct": {"Items": [
+		{"Type": "Action", "Icon": "DocumentSaveIcon", "Name": "save", "Action": "g.onSave"},
+		{"Type": "Separator"},
+		{"Type": "Action", "Icon": "HelpIcon", "Name": "help"}
+	]}}
+]}`
+
+func TestUniqueName(t *testing.T) {
+	used := map[string]bool{"title": true, "title2": true, "item3": true, "42": true}
+	for name, expected := range map[string]string{
+		"label": "label",
+		"title": "title3",
+		"item3": "item2",
+		"42":    "422",
+	} {
+		assert.Equal(t, expected, uniqueName(name, used), name)
+	}
+}
+
+func TestPasteRenames(t *testing.T) {
+	b := newTestBuilder(t, toolbarDesign)
+	root := b.root.(*fyne.Container)
+	require.Len(t, root.Objects, 3)
+
+	b.choose(root.Objects[0])
+	b.Copy()
+	b.Paste()
+	require.Len(t, root.Objects, 4)
+	pasted := root.Objects[1]
+	assert.NotSame(t, root.Objects[0], pasted)
+	assert.Equal(t, "title3", b.meta[pasted]["name"])
+	assert.Equal(t, "title", b.meta[root.Objects[0]]["name"])
+
+	b.Paste() // after the pasted label, which is now selected
+	require.Len(t, root.Objects, 5)
+	assert.Equal(t, "title4", b.meta[root.Objects[2]]["name"])
+}
+
+func TestPasteRenamesToolbarItems(t *testing.T) {
+	b := newTestBuilder(t, toolbarDesign)
+	root := b.root.(*fyne.Container)
+	bar := root.Objects[2]
+
+	b.choose(bar)
+	b.Duplicate()
+	require.Len(t, root.Objects, 4)
+	dupe := root.Objects[3]
+	require.IsType(t, &widget.Toolbar{}, dupe)
+
+	props := b.meta[dupe]
+	assert.Equal(t, "bar2", props["name"])
+	assert.Equal(t, "save2", props[guidefs.ToolbarItemKey(0, guidefs.ToolbarItemName)])
+	assert.Equal(t, "help2", props[guidefs.ToolbarItemKey(2, guidefs.ToolbarItemName)])
+	assert.Equal(t, "g.onSave", props[guidefs.ToolbarItemKey(0, guidefs.ToolbarItemAction)])
+
+	b.choose(root.Objects[0])
+	b.Copy()
+	b.choose(dupe)
+	b.Paste()
+	assert.Equal(t, "title3", b.meta[root.Objects[4]]["name"])
+}
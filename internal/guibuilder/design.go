@@ -0,0 +1,152 @@
+package guibuilder
+
+import (
+	"errors"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+var designKinds = []string{"Content", "Dialog", "Window"}
+
+// designKindValues maps the choices of designKinds to the values stored in the design.
+var designKindValues = map[string]string{
+	"Content": gui.DesignContent,
+	"Dialog":  gui.DesignDialog,
+	"Window":  gui.DesignWindow,
+}
+
+// showDesignSettings lets the user choose if the design is shown in a dialog or window by the generated code.
+func (b *Builder) showDesignSettings() {
+	props := b.meta[b.root]
+	if props == nil {
+		props = make(map[string]string)
+		b.meta[b.root] = props
+	}
+
+	kind := widget.NewSelect(designKinds, nil)
+	title := widget.NewEntry()
+	title.SetText(props[gui.DesignTitle])
+	confirm := widget.NewEntry()
+	confirm.SetPlaceHolder("No confirm button")
+	confirm.SetText(props[gui.DesignConfirm])
+	dismiss := widget.NewEntry()
+	dismiss.SetPlaceHolder("OK")
+	dismiss.SetText(props[gui.DesignDismiss])
+	closed := widget.NewEntry()
+	closed.SetPlaceHolder("method name")
+	closed.SetText(props[gui.DesignOnClosed])
+	closed.Validator = guidefs.ValidateIdentifier
+	width, height := widget.NewEntry(), widget.NewEntry()
+	width.SetPlaceHolder("Minimum")
+	width.SetText(props[gui.DesignWidth])
+	width.Validator = validateDesignSize
+	height.SetPlaceHolder("Minimum")
+	height.SetText(props[gui.DesignHeight])
+	height.Validator = validateDesignSize
+	fixed := widget.NewCheck("", nil)
+	fixed.Checked = props[gui.DesignFixed] == "true"
+
+	dialogItems := []*widget.FormItem{widget.NewFormItem("Confirm Text", confirm),
+		widget.NewFormItem("Dismiss Text", dismiss), widget.NewFormItem("On Closed", closed)}
+	windowItems := []*widget.FormItem{widget.NewFormItem("Fixed Size", fixed)}
+	items := append([]*widget.FormItem{
+		widget.NewFormItem("Shown As", kind),
+		widget.NewFormItem("Title", title),
+		widget.NewFormItem("Size", container.NewGridWithColumns(2, width, height)),
+	}, append(dialogItems, windowItems...)...)
+	kind.OnChanged = func(k string) {
+		for _, item := range dialogItems {
+			showFormItem(item, k == "Dialog")
+		}
+		for _, item := range windowItems {
+			showFormItem(item, k == "Window")
+		}
+		for _, item := range items[1:3] {
+			showFormItem(item, k != "Content")
+		}
+	}
+	for name, value := range designKindValues {
+		if value == props[gui.DesignKind] {
+			kind.SetSelected(name)
+		}
+	}
+
+	d := dialog.NewForm("Design Settings", "Apply", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		for _, k := range []string{gui.DesignKind, gui.DesignTitle, gui.DesignConfirm, gui.DesignDismiss,
+			gui.DesignOnClosed, gui.DesignWidth, gui.DesignHeight, gui.DesignFixed} {
+			delete(props, k)
+		}
+		if kind.Selected != "Content" {
+			props[gui.DesignKind] = designKindValues[kind.Selected]
+			setDesignProperty(props, gui.DesignTitle, title.Text)
+			setDesignProperty(props, gui.DesignWidth, width.Text)
+			setDesignProperty(props, gui.DesignHeight, height.Text)
+		}
+		if kind.Selected == "Dialog" {
+			setDesignProperty(props, gui.DesignConfirm, confirm.Text)
+			setDesignProperty(props, gui.DesignDismiss, dismiss.Text)
+			setDesignProperty(props, gui.DesignOnClosed, closed.Text)
+		} else if kind.Selected == "Window" && fixed.Checked {
+			props[gui.DesignFixed] = "true"
+		}
+		b.recordChange(editChange, b.root)
+	}, b.win)
+	d.Resize(fyne.NewSize(420, 0))
+	d.Show()
+}
+
+// moveDesignProperties keeps the design settings when the root of the design is replaced.
+func (b *Builder) moveDesignProperties(from, to fyne.CanvasObject) {
+	b.moveProperties(from, to, gui.IsDesignProperty)
+}
+
+// moveProperties moves the metadata with keys that match from one object to another that takes its place.
+func (b *Builder) moveProperties(from, to fyne.CanvasObject, match func(string) bool) {
+	for k, v := range b.meta[from] {
+		if !match(k) {
+			continue
+		}
+
+		if b.meta[to] == nil {
+			b.meta[to] = make(map[string]string)
+		}
+		b.meta[to][k] = v
+		delete(b.meta[from], k)
+	}
+}
+
+func setDesignProperty(props map[string]string, key, value string) {
+	if value != "" {
+		props[key] = value
+	}
+}
+
+func showFormItem(item *widget.FormItem, show bool) {
+	if show {
+		item.Widget.Show()
+	} else {
+		item.Widget.Hide()
+	}
+}
+
+func validateDesignSize(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	if f, err := strconv.ParseFloat(s, 32); err != nil || f <= 0 {
+		return errors.New("sizes must be a positive number")
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package guibuilder
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+const dropBarWidth = 3
+
+// dropTarget describes where a dragged object would be inserted into the design.
+type dropTarget struct {
+	parent fyne.CanvasObject
+	index  int    // insertion index in a *fyne.Container, -1 to append
+	slot   string // Border layout slot to assign, or "leading" or "trailing" of a Split, if any
+
+	free bool          // true if the parent uses the "Free" layout
+	at   fyne.Position // the position in a "Free" parent to place the object
+
+	// the indicator to draw, relative to the design root
+	pos  fyne.Position
+	size fyne.Size
+}
+
+// paletteItem is a component in the library list that can be dragged onto the design.
+type paletteItem struct {
+	widget.Label
+
+	b     *Builder
+	class string
+}
+
+func newPaletteItem(b *Builder) *paletteItem {
+	p := &paletteItem{b: b}
+	p.ExtendBaseWidget(p)
+	return p
+}
+
+func (p *paletteItem) Dragged(ev *fyne.DragEvent) {
+	if p.class == "" || p.b.overlay == nil {
+		return
+	}
+
+	p.b.overlay.dragOver(ev.AbsolutePosition, nil)
+}
+
+func (p *paletteItem) DragEnd() {
+	if p.class == "" || p.b.overlay == nil {
+		return
+	}
+
+	t := p.b.overlay.endDrag()
+	if t == nil {
+		return
+	}
+	info := guidefs.Lookup(p.class)
+	if info == nil {
+		return
+	}
+
+	obj := info.Create(p.b)
+	if t.free {
+		guidefs.SetFreeBounds(obj, p.b, t.at, obj.MinSize())
+	}
+	p.b.insert(t.parent, t.index, t.slot, obj)
+	p.b.choose(obj)
+	p.b.recordChange(structureChange, t.parent)
+}
+
+// absPos returns the position of o relative to the top left of the design.
+func (b *Builder) absPos(o fyne.CanvasObject) fyne.Position {
+	d := fyne.CurrentApp().Driver()
+	return d.AbsolutePositionForObject(o).Subtract(d.AbsolutePositionForObject(b.root))
+}
+
+func (b *Builder) inside(o fyne.CanvasObject, p fyne.Position) bool {
+	pos := b.absPos(o)
+	size := o.Size()
+	return p.X >= pos.X && p.Y >= pos.Y && p.X < pos.X+size.Width && p.Y < pos.Y+size.Height
+}
+
+// containerAt returns the deepest container in the design under position p, ignoring the moving object.
+func (b *Builder) containerAt(o fyne.CanvasObject, p fyne.Position, moving fyne.CanvasObject) fyne.CanvasObject {
+	if o == moving {
+		return nil
+	}
+
+	for _, child := range children(o) {
+		if child == nil || !child.Visible() || !isContainer(child) || !b.inside(child, p) {
+			continue
+		}
+
+		if found := b.containerAt(child, p, moving); found != nil {
+			return found
+		}
+	}
+
+	if isContainer(o) {
+		return o
+	}
+	return nil
+}
+
+// findDropTarget returns where an object dropped at position p, relative to the design, would be inserted.
+// If moving is not nil it is the object being dragged, which cannot be dropped into itself.
+func (b *Builder) findDropTarget(p fyne.Position, moving fyne.CanvasObject) *dropTarget {
+	parent := b.containerAt(b.root, p, moving)
+	if parent == nil {
+		return nil
+	}
+
+	if s, ok := parentOf(b.root, parent).(*container.Split); ok && b.emptySlot(parent) {
+		return b.splitDropTarget(s, p)
+	}
+
+	pos := b.absPos(parent)
+	size := parent.Size()
+	switch c := parent.(type) {
+	case *fyne.Container:
+		props := b.meta[c]
+		switch props["layout"] {
+		case "Border":
+			return b.borderDropTarget(c, p)
+		case "Free":
+			return b.freeDropTarget(c, p, moving)
+		}
+
+		return b.listDropTarget(c, p, props["layout"])
+	case *container.AppTabs:
+		return &dropTarget{parent: parent, index: -1, pos: pos, size: size}
+	case *container.Split:
+		return b.splitDropTarget(c, p)
+	}
+
+	for _, child := range children(parent) {
+		if child == nil {
+			return &dropTarget{parent: parent, index: -1, pos: pos, size: size}
+		}
+	}
+	return nil // fixed children are all in use, and they are not containers
+}
+
+// borderDropTarget picks an empty edge slot when dropping near the edge of a Border container,
+// otherwise the object is added to the middle.
+func (b *Builder) borderDropTarget(c *fyne.Container, p fyne.Position) *dropTarget {
+	props := b.meta[c]
+	pos := b.absPos(c)
+	size := c.Size()
+	rel := p.Subtract(pos)
+
+	t := &dropTarget{parent: c, index: -1, pos: pos, size: size}
+	edgeH, edgeW := size.Height/4, size.Width/4
+	switch {
+	case rel.Y < edgeH && props["top"] == "":
+		t.slot, t.size = "top", fyne.NewSize(size.Width, edgeH)
+	case rel.Y > size.Height-edgeH && props["bottom"] == "":
+		t.slot, t.size = "bottom", fyne.NewSize(size.Width, edgeH)
+		t.pos = pos.AddXY(0, size.Height-edgeH)
+	case rel.X < edgeW && props["left"] == "":
+		t.slot, t.size = "left", fyne.NewSize(edgeW, size.Height)
+	case rel.X > size.Width-edgeW && props["right"] == "":
+		t.slot, t.size = "right", fyne.NewSize(edgeW, size.Height)
+		t.pos = pos.AddXY(size.Width-edgeW, 0)
+	}
+	return t
+}
+
+// splitDropTarget picks the leading or trailing slot of a Split under the position p, if it is empty.
+func (b *Builder) splitDropTarget(s *container.Split, p fyne.Position) *dropTarget {
+	for i, child := range []fyne.CanvasObject{s.Leading, s.Trailing} {
+		if !b.inside(child, p) || !b.emptySlot(child) {
+			continue
+		}
+
+		slot := "leading"
+		if i == 1 {
+			slot = "trailing"
+		}
+		return &dropTarget{parent: s, index: -1, slot: slot, pos: b.absPos(child), size: child.Size()}
+	}
+	return nil
+}
+
+// emptySlot returns true if o is the empty stack that fills an unused slot of a container such as Split.
+func (b *Builder) emptySlot(o fyne.CanvasObject) bool {
+	c, ok := o.(*fyne.Container)
+	if !ok || len(c.Objects) > 0 {
+		return false
+	}
+
+	layout := b.meta[c]["layout"]
+	return layout == "" || layout == "Stack"
+}
+
+// freeDropTarget places the object at the pointer position, snapped to the grid, in a "Free" container.
+func (b *Builder) freeDropTarget(c *fyne.Container, p fyne.Position, moving fyne.CanvasObject) *dropTarget {
+	origin := b.absPos(c)
+	grid := guidefs.SnapGrid(c, b)
+	rel := p.Subtract(origin)
+	at := fyne.NewPos(snapGrid(rel.X, grid), snapGrid(rel.Y, grid))
+
+	size := fyne.NewSize(dropBarWidth*4, dropBarWidth*4)
+	if moving != nil {
+		size = moving.Size()
+	}
+	return &dropTarget{parent: c, index: -1, free: true, at: at, pos: origin.Add(at), size: size}
+}
+
+// listDropTarget finds the insertion index amongst the children of a container in reading order,
+// or along the axis of a box layout.
+func (b *Builder) listDropTarget(c *fyne.Container, p fyne.Position, layout string) *dropTarget {
+	var last fyne.CanvasObject
+	for i, child := range c.Objects {
+		if !child.Visible() {
+			continue
+		}
+
+		pos := b.absPos(child)
+		size := child.Size()
+		var before bool
+		switch layout {
+		case "VBox":
+			before = p.Y < pos.Y+size.Height/2
+		case "HBox":
+			before = p.X < pos.X+size.Width/2
+		default:
+			before = p.Y < pos.Y || (p.Y < pos.Y+size.Height && p.X < pos.X+size.Width/2)
+		}
+
+		if before {
+			if layout == "VBox" {
+				return &dropTarget{parent: c, index: i, pos: pos.AddXY(0, -dropBarWidth),
+					size: fyne.NewSize(size.Width, dropBarWidth)}
+			}
+			return &dropTarget{parent: c, index: i, pos: pos.AddXY(-dropBarWidth, 0),
+				size: fyne.NewSize(dropBarWidth, size.Height)}
+		}
+		last = child
+	}
+
+	if last == nil {
+		return &dropTarget{parent: c, index: -1, pos: b.absPos(c), size: c.Size()}
+	}
+	pos := b.absPos(last)
+	size := last.Size()
+	if layout == "VBox" {
+		return &dropTarget{parent: c, index: -1, pos: pos.AddXY(0, size.Height),
+			size: fyne.NewSize(size.Width, dropBarWidth)}
+	}
+	return &dropTarget{parent: c, index: -1, pos: pos.AddXY(size.Width, 0),
+		size: fyne.NewSize(dropBarWidth, size.Height)}
+}
+
+// move places an existing object of the design at a drop target.
+func (b *Builder) move(o fyne.CanvasObject, t *dropTarget) {
+	if t == nil || contains(o, t.parent) {
+		return
+	}
+
+	parent := parentOf(b.root, o)
+	if parent == nil {
+		return // the root cannot be moved
+	}
+	index := t.index
+	if c, ok := parent.(*fyne.Container); ok && parent == t.parent && index >= 0 {
+		for i, child := range c.Objects {
+			if child == o && i < index {
+				index--
+				break
+			}
+		}
+	}
+
+	if t.free {
+		guidefs.SetFreeBounds(o, b, t.at, o.Size())
+	} else {
+		guidefs.ClearFreeBounds(o, b)
+	}
+	b.detach(o)
+	b.insert(t.parent, index, t.slot, o)
+	b.choose(o)
+	b.recordChange(structureChange, parent, t.parent, o)
+}
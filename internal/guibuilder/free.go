@@ -0,0 +1,206 @@
+package guibuilder
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+const (
+	// freeHandleSize is the size of the handle, at the bottom right of a selected object, that resizes it
+	freeHandleSize = 8
+	// freeSnapDistance is how close an edge must be to another to snap to it
+	freeSnapDistance = 6
+)
+
+// freeDrag tracks an object that is being moved or resized inside a container with the "Free" layout.
+type freeDrag struct {
+	obj    fyne.CanvasObject
+	parent *fyne.Container
+	resize bool
+
+	pos   fyne.Position
+	size  fyne.Size
+	delta fyne.Position
+}
+
+// freeParent returns the container of o if it uses the "Free" layout, otherwise nil.
+func (b *Builder) freeParent(o fyne.CanvasObject) *fyne.Container {
+	if o == nil {
+		return nil
+	}
+
+	c, ok := parentOf(b.root, o).(*fyne.Container)
+	if !ok || b.meta[c]["layout"] != "Free" {
+		return nil
+	}
+	return c
+}
+
+// startFreeDrag begins moving or resizing a child of a "Free" container, for a drag that started at p,
+// relative to the design. It returns nil if the drag should move the object to another container instead.
+func (b *Builder) startFreeDrag(p fyne.Position) *freeDrag {
+	if sel := b.current; b.freeParent(sel) != nil {
+		corner := b.absPos(sel).Add(sel.Size())
+		if p.X >= corner.X-freeHandleSize && p.Y >= corner.Y-freeHandleSize && p.X <= corner.X && p.Y <= corner.Y {
+			pos, size := guidefs.FreeBounds(sel, b)
+			return &freeDrag{obj: sel, parent: b.freeParent(sel), resize: true, pos: pos, size: size}
+		}
+	}
+
+	obj := findObject(b.root, p)
+	parent := b.freeParent(obj)
+	if parent == nil {
+		return nil
+	}
+	pos, size := guidefs.FreeBounds(obj, b)
+	return &freeDrag{obj: obj, parent: parent, pos: pos, size: size}
+}
+
+// leaveFree returns true if a free move has been dragged over another container than its parent,
+// restoring the bounds of the object so that the drag can move it there instead.
+func (b *Builder) leaveFree(f *freeDrag, p fyne.Position) bool {
+	if f.resize || b.containerAt(b.root, p, f.obj) == f.parent {
+		return false
+	}
+
+	guidefs.SetFreeBounds(f.obj, b, f.pos, f.size)
+	f.parent.Refresh()
+	return true
+}
+
+// dragFree moves or resizes the object of a free drag by the distance dragged, snapping its edges.
+// It returns the snapped guide positions relative to the parent, a negative value means there is no guide.
+func (b *Builder) dragFree(f *freeDrag, dragged fyne.Delta) (guideX, guideY float32) {
+	f.delta = f.delta.AddXY(dragged.DX, dragged.DY)
+	grid := guidefs.SnapGrid(f.parent, b)
+	xs, ys := b.freeEdges(f)
+
+	pos, size := f.pos, f.size
+	if f.resize {
+		var right, bottom float32
+		right, guideX = snapEdge(pos.X+size.Width+f.delta.X, xs, grid)
+		bottom, guideY = snapEdge(pos.Y+size.Height+f.delta.Y, ys, grid)
+		size = fyne.NewSize(float32(math.Max(1, float64(right-pos.X))), float32(math.Max(1, float64(bottom-pos.Y))))
+	} else {
+		pos, guideX, guideY = snapFreePos(pos.Add(f.delta), size, xs, ys, grid)
+	}
+
+	guidefs.SetFreeBounds(f.obj, b, pos, size)
+	f.parent.Refresh()
+	return guideX, guideY
+}
+
+// freeEdges returns the horizontal and vertical edges that the object of a free drag can snap to,
+// which are the edges of its siblings and the parent container.
+func (b *Builder) freeEdges(f *freeDrag) (xs, ys []float32) {
+	xs = []float32{0, f.parent.Size().Width}
+	ys = []float32{0, f.parent.Size().Height}
+	for _, o := range f.parent.Objects {
+		if o == f.obj || !o.Visible() {
+			continue
+		}
+
+		pos, size := guidefs.FreeBounds(o, b)
+		xs = append(xs, pos.X, pos.X+size.Width)
+		ys = append(ys, pos.Y, pos.Y+size.Height)
+	}
+	return xs, ys
+}
+
+// snapFreePos snaps either edge of an object at pos to the nearest of the edges, or to the grid if none are close.
+func snapFreePos(pos fyne.Position, size fyne.Size, xs, ys []float32, grid float32) (fyne.Position, float32, float32) {
+	x, guideX := snapEdges(pos.X, size.Width, xs, grid)
+	y, guideY := snapEdges(pos.Y, size.Height, ys, grid)
+	return fyne.NewPos(x, y), guideX, guideY
+}
+
+// snapEdges returns the start of a span from start to start+length, moved so that either end meets the closest edge.
+func snapEdges(start, length float32, edges []float32, grid float32) (float32, float32) {
+	edge, startOK := nearestEdge(start, edges)
+	end, endOK := nearestEdge(start+length, edges)
+	switch {
+	case startOK && (!endOK || abs(edge-start) <= abs(end-start-length)):
+		return edge, edge
+	case endOK:
+		return end - length, end
+	}
+
+	return snapGrid(start, grid), -1
+}
+
+// snapEdge returns a single edge moved to meet the closest of the edges, or to the grid if none are close.
+func snapEdge(v float32, edges []float32, grid float32) (float32, float32) {
+	if edge, ok := nearestEdge(v, edges); ok {
+		return edge, edge
+	}
+
+	return snapGrid(v, grid), -1
+}
+
+func nearestEdge(v float32, edges []float32) (float32, bool) {
+	best, found := float32(0), false
+	for _, e := range edges {
+		if abs(e-v) < freeSnapDistance && (!found || abs(e-v) < abs(best-v)) {
+			best, found = e, true
+		}
+	}
+	return best, found
+}
+
+func snapGrid(v, grid float32) float32 {
+	if grid <= 0 {
+		return float32(math.Round(float64(v)))
+	}
+
+	return float32(math.Round(float64(v/grid))) * grid
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// freeItems returns the form items to edit the position and size of an object in a "Free" layout,
+// or nil if the parent of the object does not use it.
+func (b *Builder) freeItems(o fyne.CanvasObject) []*widget.FormItem {
+	parent := b.freeParent(o)
+	if parent == nil {
+		return nil
+	}
+
+	pos, size := guidefs.FreeBounds(o, b)
+	values := []*float32{&pos.X, &pos.Y, &size.Width, &size.Height}
+	var items []*widget.FormItem
+	for i, label := range []string{"X", "Y", "Width", "Height"} {
+		value := values[i]
+		entry := widget.NewEntry()
+		entry.SetText(strconv.FormatFloat(float64(*value), 'f', -1, 32))
+		entry.Validator = func(s string) error {
+			if _, err := strconv.ParseFloat(s, 32); err != nil {
+				return errors.New("must be a number")
+			}
+			return nil
+		}
+		entry.OnChanged = func(s string) {
+			f, err := strconv.ParseFloat(s, 32)
+			if err != nil {
+				return
+			}
+
+			*value = float32(f)
+			guidefs.SetFreeBounds(o, b, pos, size)
+			parent.Refresh()
+			b.recordChange(editChange, o)
+		}
+		items = append(items, widget.NewFormItem(label, entry))
+	}
+	return items
+}
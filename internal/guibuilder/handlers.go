@@ -0,0 +1,152 @@
+package guibuilder
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+// writeHandlerStubs adds empty methods to the handlers file of a design for any action handlers
+// that are not yet declared in the package.
+func (b *Builder) writeHandlerStubs(dir fyne.URI, name string) error {
+	stubs := &strings.Builder{}
+	existing := declaredMethods(dir, gui.HandlerReceiver(name))
+	count, err := gui.ExportHandlerStubs(b.root, b, name, existing, stubs)
+	if err != nil || count == 0 {
+		return err
+	}
+
+	return appendHandlerStubs(dir, name, stubs.String(), gui.HandlerStubImports(b.root, b, existing))
+}
+
+// checkMenuHandlers returns an error if the menu design with the same name calls a handler of this design
+// with different parameters, as they are methods of the same type.
+func (b *Builder) checkMenuHandlers(dir fyne.URI, name string) error {
+	u, err := storage.Child(dir, name+".menu.json")
+	if err != nil {
+		return err
+	}
+	r, err := storage.Reader(u)
+	if err != nil {
+		return nil // no menu for this design
+	}
+	menu, err := gui.DecodeMenu(r)
+	_ = r.Close()
+	if err != nil {
+		fyne.LogError("Failed to read menu "+u.Name(), err)
+		return nil
+	}
+
+	return gui.CheckHandlers(gui.Handlers(b.root, b), gui.MenuHandlers(menu))
+}
+
+// appendHandlerStubs adds the stub methods to the end of the handlers file for a design, creating it if needed.
+// Any of the imports that the stubs use, which the file does not import yet, are added.
+func appendHandlerStubs(dir fyne.URI, name, stubs string, imports []string) error {
+	u, err := storage.Child(dir, name+"_handlers.go")
+	if err != nil {
+		return err
+	}
+	code := "package main\n"
+	if r, err := storage.Reader(u); err == nil {
+		data, err := io.ReadAll(r)
+		_ = r.Close()
+		if err != nil {
+			return err
+		}
+		code = string(data)
+	}
+	code = addImports(code, imports)
+
+	w, err := storage.Writer(u)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, code+stubs)
+	if err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// addImports returns the Go code with an import declaration, after the package clause, for any of the paths that
+// it does not import already.
+func addImports(code string, paths []string) string {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", code, parser.ImportsOnly)
+	if err != nil {
+		fyne.LogError("Failed to parse handlers file", err)
+		return code
+	}
+
+	existing := make(map[string]bool)
+	for _, spec := range f.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err == nil {
+			existing[p] = true
+		}
+	}
+	decls := &strings.Builder{}
+	for _, p := range paths {
+		if !existing[p] {
+			fmt.Fprintf(decls, "\nimport %q\n", p)
+		}
+	}
+	if decls.Len() == 0 {
+		return code
+	}
+
+	end := fset.Position(f.Name.End()).Offset
+	return code[:end] + "\n" + decls.String() + code[end:]
+}
+
+// declaredMethods returns the names of methods on the receiver type that are declared in Go files in dir.
+func declaredMethods(dir fyne.URI, receiver string) []string {
+	items, err := storage.List(dir)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	fset := token.NewFileSet()
+	for _, u := range items {
+		if u.Extension() != ".go" || strings.HasSuffix(u.Name(), "_test.go") {
+			continue
+		}
+		r, err := storage.Reader(u)
+		if err != nil {
+			continue
+		}
+		f, err := parser.ParseFile(fset, u.Name(), r, parser.SkipObjectResolution)
+		_ = r.Close()
+		if err != nil {
+			fyne.LogError("Failed to parse "+u.Name(), err)
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+
+			typ := fn.Recv.List[0].Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if id, ok := typ.(*ast.Ident); ok && id.Name == receiver {
+				names = append(names, fn.Name.Name)
+			}
+		}
+	}
+	return names
+}
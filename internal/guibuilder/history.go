@@ -0,0 +1,341 @@
+package guibuilder
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+// coalesceDelay is how soon after an edit another edit to the same object will be merged into one undo step.
+const coalesceDelay = time.Second
+
+type changeKind int
+
+const (
+	editChange changeKind = iota
+	renameChange
+	structureChange
+)
+
+// change is an undoable command, holding the state of each object that it modified from before and after it was
+// applied. Undo and redo apply those states to the same objects, so property forms, dialogs and previews holding
+// them stay attached to the design.
+type change struct {
+	kind                  changeKind
+	objs                  []fyne.CanvasObject
+	before, after         []*objectState
+	rootBefore, rootAfter fyne.CanvasObject
+	sel                   fyne.CanvasObject // the object that was selected after the change
+	at                    time.Time
+}
+
+// objectState is what the design stores for a single object: its metadata, its fields and the objects it contains.
+// Contained objects are kept by reference, each of them has a state of its own.
+type objectState struct {
+	props   map[string]string
+	fields  map[string]interface{}
+	objects []fyne.CanvasObject
+	tabs    []*container.TabItem
+}
+
+func (s *objectState) equal(o *objectState) bool {
+	if !sameObjects(s.objects, o.objects) || len(s.tabs) != len(o.tabs) {
+		return false
+	}
+	for i, tab := range s.tabs {
+		if tab != o.tabs[i] {
+			return false
+		}
+	}
+	return reflect.DeepEqual(s.props, o.props) && reflect.DeepEqual(s.fields, o.fields)
+}
+
+// CanUndo returns true if there are changes in the history that can be undone.
+func (b *Builder) CanUndo() bool {
+	return len(b.undo) > 0
+}
+
+// CanRedo returns true if there are undone changes that can be applied again.
+func (b *Builder) CanRedo() bool {
+	return len(b.redo) > 0
+}
+
+// Undo reverts the most recent change to the design.
+func (b *Builder) Undo() {
+	if !b.CanUndo() {
+		return
+	}
+
+	c := b.undo[len(b.undo)-1]
+	b.undo = b.undo[:len(b.undo)-1]
+	b.redo = append(b.redo, c)
+	b.apply(c, c.before, c.rootBefore)
+}
+
+// Redo applies the most recently undone change again.
+func (b *Builder) Redo() {
+	if !b.CanRedo() {
+		return
+	}
+
+	c := b.redo[len(b.redo)-1]
+	b.redo = b.redo[:len(b.redo)-1]
+	b.undo = append(b.undo, c)
+	b.apply(c, c.after, c.rootAfter)
+}
+
+// recordChange adds an undo step for the objects that were modified, compared to their state after the last change.
+// Only the objects passed are compared, so a structural change passes the containers it added to or removed from.
+// Consecutive edits or renames of the same objects are merged into a single step.
+func (b *Builder) recordChange(kind changeKind, objs ...fyne.CanvasObject) {
+	c := &change{kind: kind, rootBefore: b.stateRoot, rootAfter: b.root, sel: b.current, at: time.Now()}
+	for _, o := range objs {
+		if o == nil || indexOf(c.objs, o) >= 0 {
+			continue
+		}
+
+		after := b.captureState(o)
+		before := b.states[o]
+		if before != nil && before.equal(after) {
+			continue
+		}
+		c.objs = append(c.objs, o)
+		c.before = append(c.before, before)
+		c.after = append(c.after, after)
+		b.states[o] = after
+	}
+	b.cacheStates(b.root)
+	if len(c.objs) == 0 && c.rootBefore == c.rootAfter {
+		return
+	}
+
+	b.stateRoot = b.root
+	if top := b.lastChange(); top != nil && top != b.saved && len(b.redo) == 0 && kind != structureChange &&
+		top.kind == kind && sameObjects(top.objs, c.objs) && c.at.Sub(top.at) < coalesceDelay {
+		top.after = c.after
+		top.rootAfter = c.rootAfter
+		top.sel = c.sel
+		top.at = c.at
+		b.changed()
+		return
+	}
+
+	b.undo = append(b.undo, c)
+	b.redo = nil
+	b.changed()
+}
+
+func indexOf(objs []fyne.CanvasObject, o fyne.CanvasObject) int {
+	for i, obj := range objs {
+		if obj == o {
+			return i
+		}
+	}
+	return -1
+}
+
+func sameObjects(a, b []fyne.CanvasObject) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, o := range a {
+		if o != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lastChange returns the change that would be undone next, or nil if there is none.
+func (b *Builder) lastChange() *change {
+	if len(b.undo) == 0 {
+		return nil
+	}
+	return b.undo[len(b.undo)-1]
+}
+
+func (b *Builder) changed() {
+	if b.outline != nil {
+		b.outline.Refresh()
+	}
+	if b.design != nil {
+		// lay out the design now, so that the selection matches any objects that moved or changed size
+		b.design.Refresh()
+		b.overlay.highlight(b.current)
+	}
+	if b.OnChanged != nil {
+		b.OnChanged()
+	}
+}
+
+// apply sets the objects of a change to the states given, from before or after it, and selects the changed object.
+func (b *Builder) apply(c *change, states []*objectState, root fyne.CanvasObject) {
+	for i, o := range c.objs {
+		if states[i] != nil {
+			b.applyState(o, states[i])
+		}
+	}
+	b.root = root
+	b.stateRoot = root
+	if b.design != nil {
+		b.design.Objects[0] = root
+	}
+
+	sel := c.sel
+	if !contains(b.root, sel) {
+		sel = b.root
+		for _, o := range c.objs {
+			if contains(b.root, o) {
+				sel = o
+				break
+			}
+		}
+	}
+	b.choose(sel)
+	b.changed()
+}
+
+// captureState returns the current state of a single object in the design.
+func (b *Builder) captureState(o fyne.CanvasObject) *objectState {
+	s := &objectState{props: make(map[string]string)}
+	for k, v := range b.meta[o] {
+		s.props[k] = v
+	}
+
+	fields, err := gui.EncodeProperties(o, b)
+	if err != nil {
+		fyne.LogError("Failed to encode object properties", err)
+	}
+	s.fields = fields
+	s.objects = append([]fyne.CanvasObject{}, children(o)...)
+	if tabs, ok := o.(*container.AppTabs); ok {
+		s.tabs = append([]*container.TabItem{}, tabs.Items...)
+	}
+	return s
+}
+
+// applyState sets an object back to a state returned by captureState.
+// The metadata map is updated in place, as editors for the object may still be holding it.
+func (b *Builder) applyState(o fyne.CanvasObject, s *objectState) {
+	props := b.meta[o]
+	if props == nil {
+		props = make(map[string]string)
+		b.meta[o] = props
+	}
+	for k := range props {
+		delete(props, k)
+	}
+	for k, v := range s.props {
+		props[k] = v
+	}
+
+	switch p := o.(type) {
+	case *fyne.Container:
+		p.Objects = append([]fyne.CanvasObject{}, s.objects...)
+	case *container.AppTabs:
+		for i, tab := range s.tabs {
+			tab.Content = s.objects[i]
+		}
+		p.SetItems(append([]*container.TabItem{}, s.tabs...))
+	case *container.Split:
+		p.Leading, p.Trailing = s.objects[0], s.objects[1]
+	case *container.Scroll:
+		p.Content = s.objects[0]
+	case *container.ThemeOverride:
+		p.Content = s.objects[0]
+	case *widget.Card:
+		p.Content = s.objects[0]
+	}
+
+	if err := gui.DecodeProperties(o, s.fields, b); err != nil {
+		fyne.LogError("Failed to apply object properties", err)
+	}
+	b.states[o] = s
+}
+
+// cacheStates stores the state of any objects from o down that have not been seen before,
+// so that the next change to them can be undone.
+func (b *Builder) cacheStates(o fyne.CanvasObject) {
+	walk(o, func(obj fyne.CanvasObject) {
+		if _, ok := b.states[obj]; !ok {
+			b.states[obj] = b.captureState(obj)
+		}
+	})
+}
+
+// allObjects returns every object in the design, for changes that can modify any of them.
+func (b *Builder) allObjects() []fyne.CanvasObject {
+	var objs []fyne.CanvasObject
+	walk(b.root, func(o fyne.CanvasObject) {
+		objs = append(objs, o)
+	})
+	return objs
+}
+
+func (b *Builder) snapshot() []byte {
+	tree, err := gui.EncodeMap(b.root, b)
+	if err != nil {
+		fyne.LogError("Failed to encode design", err)
+		return nil
+	}
+
+	data, err := json.Marshal(tree)
+	if err != nil {
+		fyne.LogError("Failed to encode design", err)
+		return nil
+	}
+	return data
+}
+
+// children returns the objects contained in o, if it is a container.
+func children(o fyne.CanvasObject) []fyne.CanvasObject {
+	if o == nil {
+		return nil
+	}
+	if c, ok := o.(*fyne.Container); ok {
+		return c.Objects
+	}
+
+	info := guidefs.Lookup(reflect.TypeOf(o).String())
+	if info == nil || !info.IsContainer() {
+		return nil
+	}
+	return info.Children(o)
+}
+
+// pathTo returns the child indexes that lead from root to o, or nil if it is not found.
+func pathTo(root, o fyne.CanvasObject) []int {
+	if o == nil || root == o {
+		return []int{}
+	}
+
+	for i, child := range children(root) {
+		if child == nil {
+			continue
+		}
+		if sub := pathTo(child, o); sub != nil {
+			return append([]int{i}, sub...)
+		}
+	}
+	return nil
+}
+
+// objectAt returns the object found by following the child indexes of path from root.
+func objectAt(root fyne.CanvasObject, path []int) fyne.CanvasObject {
+	o := root
+	for _, i := range path {
+		objs := children(o)
+		if i >= len(objs) {
+			return nil
+		}
+		o = objs[i]
+	}
+	return o
+}
@@ -0,0 +1,162 @@
+package guibuilder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDesign = `{"Type": "*fyne.Container", "Layout": "VBox", "Objects": [
+	{"Type": "*widget.Label", "Name": "title", "Struct": {"Text": "Hello"}},
+	{"Type": "*widget.Button", "Name": "ok", "Struct": {"Text": "OK"}}
+]}`
+
+// newTestBuilder opens a builder for the design, saved as a file in a temporary directory.
+func newTestBuilder(t *testing.T, design string) *Builder {
+	test.NewApp()
+	path := filepath.Join(t.TempDir(), "test.gui.json")
+	require.NoError(t, os.WriteFile(path, []byte(design), 0644))
+
+	b := NewBuilder(storage.NewFileURI(path), test.NewWindow(nil))
+	require.IsType(t, &fyne.Container{}, b.root)
+	return b
+}
+
+func TestUndoRedoEdit(t *testing.T) {
+	b := newTestBuilder(t, testDesign)
+	root := b.root.(*fyne.Container)
+	label := root.Objects[0].(*widget.Label)
+	assert.False(t, b.CanUndo())
+
+	label.SetText("Bye")
+	b.recordChange(editChange, label)
+	assert.True(t, b.CanUndo())
+	assert.True(t, b.Changed())
+
+	b.Undo()
+	assert.Same(t, label, root.Objects[0])
+	assert.Equal(t, "Hello", label.Text)
+	assert.False(t, b.CanUndo())
+	assert.True(t, b.CanRedo())
+	assert.False(t, b.Changed())
+
+	b.Redo()
+	assert.Same(t, label, root.Objects[0])
+	assert.Equal(t, "Bye", label.Text)
+	assert.True(t, b.Changed())
+}
+
+func TestUndoRedoRename(t *testing.T) {
+	b := newTestBuilder(t, testDesign)
+	label := b.root.(*fyne.Container).Objects[0]
+	props := b.meta[label]
+
+	props["name"] = "heading"
+	b.recordChange(renameChange, label)
+	b.Undo()
+	assert.Equal(t, "title", props["name"])
+
+	b.Redo()
+	assert.Equal(t, "heading", props["name"])
+}
+
+func TestUndoRedoLayout(t *testing.T) {
+	b := newTestBuilder(t, testDesign)
+	root := b.root.(*fyne.Container)
+	props := b.meta[root]
+
+	props["layout"] = "HBox"
+	b.relayout(root)
+	b.recordChange(editChange, root)
+
+	b.Undo()
+	assert.Equal(t, "VBox", props["layout"])
+	assert.Same(t, root, b.root)
+	b.Redo()
+	assert.Equal(t, "HBox", props["layout"])
+}
+
+func TestUndoRedoStructure(t *testing.T) {
+	b := newTestBuilder(t, testDesign)
+	root := b.root.(*fyne.Container)
+	label, button := root.Objects[0], root.Objects[1]
+
+	b.remove(label)
+	assert.Equal(t, []fyne.CanvasObject{button}, root.Objects)
+	b.Undo()
+	assert.Equal(t, []fyne.CanvasObject{label, button}, root.Objects)
+	assert.Equal(t, "title", b.meta[label]["name"])
+	b.Redo()
+	assert.Equal(t, []fyne.CanvasObject{button}, root.Objects)
+	b.Undo()
+
+	b.moveBy(label, 1)
+	assert.Equal(t, []fyne.CanvasObject{button, label}, root.Objects)
+	b.Undo()
+	assert.Equal(t, []fyne.CanvasObject{label, button}, root.Objects)
+
+	b.choose(button)
+	b.Duplicate()
+	require.Len(t, root.Objects, 3)
+	b.Undo()
+	assert.Equal(t, []fyne.CanvasObject{label, button}, root.Objects)
+
+	b.wrap(button, wrapLayoutPrefix+"HBox")
+	wrapper := root.Objects[1]
+	assert.Equal(t, []fyne.CanvasObject{button}, wrapper.(*fyne.Container).Objects)
+	b.Undo()
+	assert.Equal(t, []fyne.CanvasObject{label, button}, root.Objects)
+	b.Redo()
+	assert.Same(t, wrapper, root.Objects[1])
+}
+
+func TestUndoCoalesce(t *testing.T) {
+	b := newTestBuilder(t, testDesign)
+	label := b.root.(*fyne.Container).Objects[0].(*widget.Label)
+
+	label.SetText("H")
+	b.recordChange(editChange, label)
+	label.SetText("Hi")
+	b.recordChange(editChange, label)
+	assert.Len(t, b.undo, 1)
+
+	b.lastChange().at = time.Now().Add(-coalesceDelay)
+	label.SetText("Hi!")
+	b.recordChange(editChange, label)
+	assert.Len(t, b.undo, 2)
+
+	b.recordChange(structureChange, label) // nothing has changed
+	assert.Len(t, b.undo, 2)
+
+	b.Undo()
+	assert.Equal(t, "Hi", label.Text)
+	b.Undo()
+	assert.Equal(t, "Hello", label.Text)
+}
+
+func TestUndoRedoReset(t *testing.T) {
+	b := newTestBuilder(t, testDesign)
+	label := b.root.(*fyne.Container).Objects[0].(*widget.Label)
+
+	label.SetText("Bye")
+	b.recordChange(editChange, label)
+	b.Undo()
+	assert.True(t, b.CanRedo())
+
+	label.SetText("Again")
+	b.recordChange(editChange, label)
+	assert.False(t, b.CanRedo())
+	assert.Len(t, b.undo, 1)
+
+	b.Undo()
+	assert.Equal(t, "Hello", label.Text)
+}
@@ -0,0 +1,139 @@
+package guibuilder
+
+import (
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+// noFocus is the initial focus option that leaves the focus to the user.
+const noFocus = "(None)"
+
+// shortcutBinding is a keyboard shortcut being edited, and the handler it calls.
+type shortcutBinding struct {
+	keys, handler string
+}
+
+// showKeyboardSettings lets the user set the widget to focus first, and the shortcuts of the design.
+func (b *Builder) showKeyboardSettings() {
+	props := b.meta[b.root]
+	if props == nil {
+		props = make(map[string]string)
+		b.meta[b.root] = props
+	}
+
+	var bindings []*shortcutBinding
+	for keys, handler := range gui.Shortcuts(props) {
+		bindings = append(bindings, &shortcutBinding{keys: keys, handler: handler})
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].keys < bindings[j].keys
+	})
+
+	focus := widget.NewSelect(append([]string{noFocus}, b.focusableNames()...), nil)
+	focus.SetSelected(noFocus)
+	if name := gui.InitialFocus(props); name != "" {
+		focus.SetSelected(name)
+	}
+
+	shortcutList := container.NewVBox()
+	var refreshShortcuts func()
+	refreshShortcuts = func() {
+		shortcutList.Objects = nil
+		for i, s := range bindings {
+			index := i
+			shortcutList.Add(shortcutRow(s, func() {
+				bindings = append(bindings[:index], bindings[index+1:]...)
+				refreshShortcuts()
+			}))
+		}
+		shortcutList.Refresh()
+	}
+	refreshShortcuts()
+	addShortcut := widget.NewButtonWithIcon("Add Shortcut", theme.ContentAddIcon(), func() {
+		bindings = append(bindings, &shortcutBinding{})
+		refreshShortcuts()
+	})
+
+	help := widget.NewLabel("The generated setupWindow method focuses the initial widget. Tab then moves through " +
+		"the widgets in the order of the layout, Fyne does not support another order. Generated windows and " +
+		"dialogs call it, otherwise call it after setting the window content.")
+	help.Wrapping = fyne.TextWrapWord
+	content := container.NewVBox(
+		widget.NewLabelWithStyle("Initial Focus", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		focus,
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Shortcuts", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		shortcutList, addShortcut)
+
+	d := dialog.NewCustomConfirm("Keyboard", "Apply", "Cancel",
+		container.NewBorder(help, nil, nil, nil, container.NewVScroll(content)), func(ok bool) {
+			if !ok {
+				return
+			}
+
+			for k := range props {
+				if k == gui.DesignFocus || strings.HasPrefix(k, gui.DesignShortcutPrefix) {
+					delete(props, k)
+				}
+			}
+			if focus.Selected != noFocus && focus.Selected != "" {
+				props[gui.DesignFocus] = focus.Selected
+			}
+			for _, s := range bindings {
+				if _, err := gui.ParseShortcut(s.keys); err != nil || s.keys == "" ||
+					s.handler == "" || guidefs.ValidateIdentifier(s.handler) != nil {
+					continue
+				}
+				props[gui.DesignShortcutPrefix+s.keys] = s.handler
+			}
+			b.recordChange(editChange, b.root)
+		}, b.win)
+	d.Resize(fyne.NewSize(480, 480))
+	d.Show()
+}
+
+// focusableNames returns the variable names of widgets in the design that can be focused.
+func (b *Builder) focusableNames() []string {
+	var names []string
+	walk(b.root, func(o fyne.CanvasObject) {
+		name := b.meta[o]["name"]
+		if _, ok := o.(fyne.Focusable); ok && name != "" {
+			names = append(names, name)
+		}
+	})
+	sort.Strings(names)
+	return names
+}
+
+func shortcutRow(s *shortcutBinding, remove func()) fyne.CanvasObject {
+	keys := widget.NewEntry()
+	keys.SetPlaceHolder("Ctrl+S")
+	keys.SetText(s.keys)
+	keys.Validator = func(text string) error {
+		_, err := gui.ParseShortcut(text)
+		return err
+	}
+	keys.OnChanged = func(text string) {
+		s.keys = text
+	}
+
+	handler := widget.NewEntry()
+	handler.SetPlaceHolder("method name")
+	handler.SetText(s.handler)
+	handler.Validator = guidefs.ValidateIdentifier
+	handler.OnChanged = func(text string) {
+		s.handler = text
+	}
+
+	del := widget.NewButtonWithIcon("", theme.DeleteIcon(), remove)
+	return container.NewBorder(nil, nil, nil, del, container.NewGridWithColumns(2, keys, handler))
+}
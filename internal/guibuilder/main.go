@@ -1,15 +1,11 @@
 package guibuilder
 
 import (
-	"os"
-	"os/exec"
-	"path/filepath"
 	"reflect"
 	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/data/validation"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/storage"
@@ -20,12 +16,6 @@ import (
 	"github.com/fyne-io/defyne/pkg/gui"
 )
 
-var (
-	editForm    *widget.Form
-	widName     *widget.Entry
-	paletteList *fyne.Container
-)
-
 // Builder is a simple type handle for a GUI builder instance.
 type Builder struct {
 	gui.DefyneContext
@@ -35,6 +25,23 @@ type Builder struct {
 	win           fyne.Window
 	meta          map[fyne.CanvasObject]map[string]string
 	th            fyne.Theme
+
+	design     *fyne.Container
+	overlay    *overlay
+	outline    *widget.Tree
+	editForm   *widget.Form
+	widName    *widget.Entry
+	properties *fyne.Container
+	undo, redo []*change
+	saved      *change                            // the last change when the design was loaded or saved
+	states     map[fyne.CanvasObject]*objectState // the state of each object after the last change
+	stateRoot  fyne.CanvasObject                  // the root of the design after the last change
+	styles     []guidefs.Style                    // the project styles that were last applied to the design
+
+	// OnChanged is called whenever the design is modified, including by undo or redo.
+	OnChanged func()
+	// OnStylesChanged is called after the project styles are edited, so that other open designs can be updated.
+	OnStylesChanged func()
 }
 
 // NewBuilder returns an instance of the GUI builder for the specified URI.
@@ -47,7 +54,10 @@ func NewBuilder(u fyne.URI, win fyne.Window) *Builder {
 	}
 
 	meta := make(map[fyne.CanvasObject]map[string]string)
-	builder := &Builder{uri: u, win: win, meta: meta}
+	builder := &Builder{uri: u, win: win, meta: meta, states: make(map[fyne.CanvasObject]*objectState)}
+	guidefs.LoadProjectLayouts(builder)
+	guidefs.LoadProjectStyles(builder)
+	guidefs.LoadProjectStrings(builder)
 	var obj fyne.CanvasObject
 	if r == nil {
 		obj = previewUI()
@@ -67,6 +77,9 @@ func NewBuilder(u fyne.URI, win fyne.Window) *Builder {
 	}
 
 	builder.root = obj
+	builder.styles = guidefs.Styles(builder)
+	builder.stateRoot = obj
+	builder.cacheStates(obj)
 	return builder
 }
 
@@ -74,6 +87,27 @@ func (b *Builder) Metadata() map[fyne.CanvasObject]map[string]string {
 	return b.meta
 }
 
+// ProjectRoot returns the directory of the Go module that the edited file belongs to.
+// If no module could be found it returns the directory containing the file.
+func (b *Builder) ProjectRoot() fyne.URI {
+	dir, err := storage.Parent(b.uri)
+	if err != nil {
+		return nil
+	}
+
+	parent := dir
+	for err == nil {
+		if mod, modErr := storage.Child(parent, "go.mod"); modErr == nil {
+			if ok, _ := storage.Exists(mod); ok {
+				return parent
+			}
+		}
+
+		parent, err = storage.Parent(parent)
+	}
+	return dir
+}
+
 func (b *Builder) Theme() fyne.Theme {
 	return b.th
 }
@@ -83,76 +117,63 @@ func (b *Builder) MakeUI() fyne.CanvasObject {
 	return b.buildUI(b.root)
 }
 
-// Run generates a go main function and runs it so we can preview the UI in a real app.
-func (b *Builder) Run() {
-	path := filepath.Join(os.TempDir(), "fynebuilder")
-	dir := storage.NewFileURI(path)
-	storage.CreateListable(dir)
-	goURI, err := storage.Child(dir, "main.go")
+// Save will trigger the current state to be written out to the file this was opened from.
+func (b *Builder) Save() error {
+	name := strings.ReplaceAll(b.uri.Name(), ".gui.json", "")
+	dir, _ := storage.Parent(b.uri)
+	err := b.checkMenuHandlers(dir, name)
 	if err != nil {
-		fyne.LogError("Failed to write temporary code", err)
-		return
+		return err
+	}
+	err = b.exportGo(dir, name)
+	if err != nil {
+		return err
 	}
 
-	w, err := storage.Writer(goURI)
+	err = b.writeHandlerStubs(dir, name)
 	if err != nil {
-		fyne.LogError("Failed get storage writer", err)
-		return
+		return err
 	}
-	err = gui.ExportGoPreview(b.root, b, w)
+	err = b.writeStringsGo(dir)
 	if err != nil {
-		fyne.LogError("Failed to export go preview", err)
-		return
+		return err
 	}
-
-	pwd, _ := os.Getwd()
-	os.Chdir(path)
-	cmd := exec.Command("go", "mod", "init", "temp")
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
-	cmd.Run()
-	cmd = exec.Command("go", "mod", "tidy")
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
-	cmd.Run()
-	cmd = exec.Command("go", "run", ".")
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
-	cmd.Start()
-	os.Chdir(pwd)
-}
-
-// Save will trigger the current state to be written out to the file this was opened from.
-func (b *Builder) Save() error {
-	name := strings.ReplaceAll(b.uri.Name(), ".gui.json", "")
-	goFile := name + ".gui.go"
-	dir, _ := storage.Parent(b.uri)
-
-	goURI, err := storage.Child(dir, goFile)
+	err = b.writeResourcesGo(dir)
 	if err != nil {
 		return err
 	}
 
-	w, err := storage.Writer(goURI)
+	w, err := storage.Writer(b.uri)
 	if err != nil {
 		return err
 	}
-	err = gui.ExportGo(b.root, b, name, w)
+	err = b.save(w)
 	if err != nil {
 		return err
 	}
 
-	_ = w.Close()
+	b.saved = b.lastChange()
+	return nil
+}
 
-	w, err = storage.Writer(b.uri)
+// Changed returns true if the design has been modified since it was loaded or last saved.
+func (b *Builder) Changed() bool {
+	return b.lastChange() != b.saved
+}
+
+// exportGo writes the generated Go code for the design to name.gui.go in the directory.
+func (b *Builder) exportGo(dir fyne.URI, name string) error {
+	goURI, err := storage.Child(dir, name+".gui.go")
 	if err != nil {
 		return err
 	}
-	err = b.save(w)
+
+	w, err := storage.Writer(goURI)
 	if err != nil {
 		return err
 	}
-
+	err = gui.ExportGo(b.root, b, name, w)
+	_ = w.Close()
 	return err
 }
 
@@ -185,12 +206,14 @@ func (b *Builder) buildLibrary() fyne.CanvasObject {
 	list := widget.NewList(func() int {
 		return len(tempNames)
 	}, func() fyne.CanvasObject {
-		return widget.NewLabel("")
+		return newPaletteItem(b)
 	}, func(i widget.ListItemID, obj fyne.CanvasObject) {
 		if i >= len(tempNames) {
 			return
 		}
-		obj.(*widget.Label).SetText(guidefs.Lookup(tempNames[i]).Name)
+		item := obj.(*paletteItem)
+		item.class = tempNames[i]
+		item.SetText(guidefs.Lookup(tempNames[i]).Name)
 	})
 	list.OnSelected = func(i widget.ListItemID) {
 		match := guidefs.Lookup(tempNames[i])
@@ -225,14 +248,18 @@ func (b *Builder) buildLibrary() fyne.CanvasObject {
 				c.Refresh()
 				// cause property editor to refresh
 				b.choose(c)
+				b.recordChange(structureChange, c)
 			}
 			return
 		}
 
 		class := reflect.TypeOf(b.current).String()
 		if wid := guidefs.Lookup(class); wid != nil && wid.IsContainer() {
-			wid.AddChild(b.current, selected.Create(b))
-
+			if selected != nil {
+				wid.AddChild(b.current, selected.Create(b))
+				b.choose(b.current)
+				b.recordChange(structureChange, b.current)
+			}
 			return
 		}
 
@@ -241,82 +268,89 @@ func (b *Builder) buildLibrary() fyne.CanvasObject {
 }
 
 func (b *Builder) buildUI(content fyne.CanvasObject) fyne.CanvasObject {
-	wrap := container.NewStack(b.root, newOverlay(b))
+	b.overlay = newOverlay(b)
+	wrap := container.NewStack(b.root, b.overlay)
+	b.design = wrap
 
-	widName = widget.NewEntry()
-	widName.Validator = validation.NewRegexp("^$|^[a-zA-Z_][a-zA-Z0-9_]*$", "Invalid variable name")
-	paletteList = container.NewVBox()
+	b.widName = widget.NewEntry()
+	b.widName.Validator = guidefs.ValidateIdentifier
+	b.properties = container.NewVBox()
 	palette := container.NewBorder(
-		widget.NewForm(widget.NewFormItem("Variable", widName)), nil, nil, nil,
-		container.NewGridWithRows(2, widget.NewCard("Properties", "",
-			container.NewVScroll(paletteList)),
+		widget.NewForm(widget.NewFormItem("Variable", b.widName)), nil, nil, nil,
+		container.NewGridWithRows(3,
+			widget.NewCard("Outline", "", b.buildOutline()),
+			widget.NewCard("Properties", "", container.NewVScroll(b.properties)),
 			widget.NewCard("Component List", "", b.buildLibrary()),
 		))
 
-	split := container.NewHSplit(wrap, palette)
+	split := container.NewHSplit(b.buildSurface(), palette)
 	split.Offset = 0.8
 	return split
 }
 
 func (b *Builder) choose(o fyne.CanvasObject) {
 	b.current = o
+	if b.overlay != nil {
+		b.overlay.highlight(o)
+	}
+	b.selectInOutline(o)
+	if b.properties == nil {
+		return // the UI has not been built yet
+	}
 
 	name := b.meta[o]["name"]
-	widName.OnChanged = func(s string) {
+	b.widName.OnChanged = func(s string) {
 		props := b.meta[o]
 		if props == nil {
 			b.meta[o] = make(map[string]string)
 		}
 		b.meta[o]["name"] = s
+		b.recordChange(renameChange, o)
 	}
-	widName.SetText(name)
+	b.widName.SetText(name)
 
 	props := b.meta[o]
 	if props == nil {
 		props = make(map[string]string)
+		b.meta[o] = props
 	}
 	nameItem := widget.NewFormItem("Type", widget.NewLabel(gui.NameOf(o)))
-	editForm = widget.NewForm()
+	b.editForm = widget.NewForm()
 	items := gui.EditorFor(o, b, func(items []*widget.FormItem) {
-		editForm.Items = nil
-		editForm.Refresh()
-		editForm.Items = append([]*widget.FormItem{nameItem}, items...)
-		editForm.Refresh()
-	}, nil)
-
-	items = append([]*widget.FormItem{nameItem}, items...)
-	b.meta[o] = props
+		b.editForm.Items = nil
+		b.editForm.Refresh()
+		b.editForm.Items = append(append([]*widget.FormItem{nameItem}, b.freeItems(o)...), items...)
+		b.editForm.Refresh()
+	}, func() {
+		// a new layout can change the properties of the children too, such as positions in a "Free" layout
+		b.recordChange(editChange, append([]fyne.CanvasObject{o}, children(o)...)...)
+	})
 
-	editForm.Items = items
-	remove := widget.NewButton("Remove", func() {
-		parent := findParent(b.current, b.root)
-		if parent == nil {
-			return
-		}
+	items = append(append([]*widget.FormItem{nameItem}, b.freeItems(o)...), items...)
 
-		parent.Remove(b.current)
-		parent.Refresh()
-		b.choose(parent)
+	b.editForm.Items = items
+	unwrap := widget.NewButton("Unwrap", func() {
+		b.unwrap(b.current)
 	})
-	paletteList.Objects = []fyne.CanvasObject{editForm, remove}
-	paletteList.Refresh()
-}
-
-func findParent(o fyne.CanvasObject, parent fyne.CanvasObject) *fyne.Container {
-	switch w := parent.(type) {
-	case *fyne.Container:
-		for _, child := range w.Objects {
-			if child == o {
-				return w
-			}
-			if found := findParent(o, child); found != nil {
-				return found
-			}
-		}
-		return nil
+	if onlyChild(o) == nil {
+		unwrap.Disable()
 	}
-
-	return nil
+	arrange := container.NewGridWithColumns(2,
+		widget.NewButtonWithIcon("Move First", theme.MoveUpIcon(), func() {
+			b.moveTo(b.current, false)
+		}),
+		widget.NewButtonWithIcon("Move Last", theme.MoveDownIcon(), func() {
+			b.moveTo(b.current, true)
+		}),
+		widget.NewButton("Wrap...", func() {
+			b.showWrap(b.current)
+		}),
+		unwrap)
+	remove := widget.NewButton("Remove", func() {
+		b.remove(b.current)
+	})
+	b.properties.Objects = []fyne.CanvasObject{b.editForm, arrange, remove}
+	b.properties.Refresh()
 }
 
 func previewUI() fyne.CanvasObject {
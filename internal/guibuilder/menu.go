@@ -0,0 +1,507 @@
+package guibuilder
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+const (
+	menuMainID = "main"
+	menuTrayID = "tray"
+)
+
+// MenuBuilder is an editor for the main menu and tray menu designs stored in a ".menu.json" file.
+type MenuBuilder struct {
+	uri    fyne.URI
+	win    fyne.Window
+	design *gui.MenuDesign
+
+	tree       *widget.Tree
+	properties *fyne.Container
+	selected   widget.TreeNodeID
+	last       []byte
+	saved      []byte // the encoded design as it was last loaded or saved
+
+	// OnChanged is called whenever the menu design is modified.
+	OnChanged func()
+}
+
+// NewMenuBuilder returns an instance of the menu builder for the specified URI.
+// The Window parameter allows presenting dialogs etc.
+func NewMenuBuilder(u fyne.URI, win fyne.Window) *MenuBuilder {
+	guidefs.InitOnce()
+	m := &MenuBuilder{uri: u, win: win, design: &gui.MenuDesign{}}
+	if r, err := storage.Reader(u); err == nil {
+		d, err := gui.DecodeMenu(r)
+		_ = r.Close()
+		if err != nil {
+			dialog.ShowError(err, win)
+		} else {
+			m.design = d
+		}
+	}
+
+	m.last = m.encode()
+	m.saved = m.last
+	return m
+}
+
+// Changed returns true if the menus have been modified since they were loaded or last saved.
+func (m *MenuBuilder) Changed() bool {
+	return !bytes.Equal(m.last, m.saved)
+}
+
+// MakeUI builds the UI for the current menu builder.
+func (m *MenuBuilder) MakeUI() fyne.CanvasObject {
+	m.tree = &widget.Tree{
+		ChildUIDs: m.childIDs,
+		IsBranch: func(id widget.TreeNodeID) bool {
+			return len(m.childIDs(id)) > 0 || id == menuMainID || id == menuTrayID || m.menuAt(id) != nil
+		},
+		CreateNode: func(bool) fyne.CanvasObject {
+			return container.NewHBox(widget.NewIcon(nil), widget.NewLabel("Template Menu Item"))
+		},
+		UpdateNode: func(id widget.TreeNodeID, _ bool, o fyne.CanvasObject) {
+			row := o.(*fyne.Container)
+			text, icon := m.nodeLabel(id)
+			row.Objects[0].(*widget.Icon).SetResource(icon)
+			row.Objects[1].(*widget.Label).SetText(text)
+		},
+		OnSelected: m.choose,
+	}
+	m.tree.ExtendBaseWidget(m.tree)
+	m.tree.OpenAllBranches()
+
+	m.properties = container.NewVBox()
+	actions := container.NewHBox(
+		widget.NewButtonWithIcon("Menu", theme.ContentAddIcon(), func() {
+			m.design.Main = append(m.design.Main, &gui.MenuDesignMenu{Label: "Menu"})
+			m.changed(menuMainID + "/" + strconv.Itoa(len(m.design.Main)-1))
+		}),
+		widget.NewButtonWithIcon("Item", theme.ContentAddIcon(), func() {
+			m.addItem(&gui.MenuDesignItem{Label: "Item"}, false)
+		}),
+		widget.NewButtonWithIcon("Sub-menu Item", theme.ContentAddIcon(), func() {
+			m.addItem(&gui.MenuDesignItem{Label: "Item"}, true)
+		}),
+		widget.NewButtonWithIcon("Separator", theme.ContentAddIcon(), func() {
+			m.addItem(&gui.MenuDesignItem{Separator: true}, false)
+		}),
+		layout.NewSpacer(),
+		widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() {
+			m.moveBy(-1)
+		}),
+		widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() {
+			m.moveBy(1)
+		}),
+		widget.NewButtonWithIcon("", theme.DeleteIcon(), m.remove),
+	)
+
+	split := container.NewHSplit(
+		container.NewBorder(actions, nil, nil, nil, m.tree),
+		widget.NewCard("Properties", "", container.NewVScroll(m.properties)))
+	split.Offset = 0.6
+	return split
+}
+
+// Run opens a window using the menus so that they can be tried out.
+// Tapping an item shows the name of its handler.
+func (m *MenuBuilder) Run() {
+	status := widget.NewLabel("Use the menus to test them, actions will be listed here")
+	mainMenu, tray := gui.BuildMenu(m.design, func(name string) {
+		status.SetText("Called " + name)
+	})
+
+	w := fyne.CurrentApp().NewWindow("Preview: " + m.uri.Name())
+	var trayButton *widget.Button
+	trayButton = widget.NewButtonWithIcon("Tray Menu", theme.MenuIcon(), func() {
+		widget.ShowPopUpMenuAtRelativePosition(tray, w.Canvas(), fyne.NewPos(0, trayButton.Size().Height), trayButton)
+	})
+	if tray == nil {
+		trayButton.Disable()
+	}
+
+	w.SetMainMenu(mainMenu)
+	w.SetContent(container.NewBorder(nil, status, nil, nil, container.NewCenter(trayButton)))
+	w.Resize(fyne.NewSize(480, 320))
+	w.Show()
+}
+
+// CodeNotGeneratedError is returned by MenuBuilder.Save when the menu design was saved, but its Go code was not.
+type CodeNotGeneratedError struct {
+	Reason string
+}
+
+func (e *CodeNotGeneratedError) Error() string {
+	return "The menu was saved, but its code was not generated: " + e.Reason
+}
+
+// Save will write the menu design, and the Go code to create it, next to the file this was opened from.
+// The code adds methods to the type of the GUI design with the same name, so it is only generated if that exists,
+// returning a *CodeNotGeneratedError otherwise. Nothing is written if the design uses the same handler names
+// with other parameters.
+func (m *MenuBuilder) Save() error {
+	name := strings.ReplaceAll(m.uri.Name(), ".menu.json", "")
+	dir, _ := storage.Parent(m.uri)
+
+	designURI, err := storage.Child(dir, name+".gui.json")
+	if err != nil {
+		return err
+	}
+	hasDesign, _ := storage.Exists(designURI)
+	if hasDesign {
+		if err = m.checkDesignHandlers(designURI); err != nil {
+			return err
+		}
+	}
+
+	w, err := storage.Writer(m.uri)
+	if err != nil {
+		return err
+	}
+	err = gui.EncodeMenu(m.design, w)
+	_ = w.Close()
+	if err != nil {
+		return err
+	}
+	m.saved = m.last
+
+	if !hasDesign {
+		return &CodeNotGeneratedError{Reason: fmt.Sprintf("it adds methods to the design %s, create it first.",
+			designURI.Name())}
+	}
+
+	goURI, err := storage.Child(dir, name+".menu.go")
+	if err != nil {
+		return err
+	}
+	w, err = storage.Writer(goURI)
+	if err != nil {
+		return err
+	}
+	err = gui.ExportMenuGo(m.design, name, w)
+	_ = w.Close()
+	if err != nil {
+		return err
+	}
+
+	stubs := &strings.Builder{}
+	count, err := gui.ExportMenuHandlerStubs(m.design, name,
+		declaredMethods(dir, gui.HandlerReceiver(name)), stubs)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return appendHandlerStubs(dir, name, stubs.String(), nil)
+	}
+	return nil
+}
+
+// checkDesignHandlers returns an error if the menu and the GUI design at u call a handler with different parameters.
+func (m *MenuBuilder) checkDesignHandlers(u fyne.URI) error {
+	r, err := storage.Reader(u)
+	if err != nil {
+		return err
+	}
+	design := &Builder{uri: u, win: m.win, meta: make(map[fyne.CanvasObject]map[string]string)}
+	obj, _, err := gui.DecodeObject(r, design)
+	_ = r.Close()
+	if err != nil || obj == nil {
+		fyne.LogError("Failed to read design "+u.Name(), err)
+		return nil
+	}
+
+	return gui.CheckHandlers(gui.MenuHandlers(m.design), gui.Handlers(obj, design))
+}
+
+func (m *MenuBuilder) addItem(item *gui.MenuDesignItem, child bool) {
+	id := m.selected
+	if id == "" || id == menuMainID {
+		dialog.ShowInformation("Add Item", "Select a menu, or the tray menu, to add items to", m.win)
+		return
+	}
+
+	if it := m.itemAt(id); it != nil && !child {
+		// add after the selected item
+		parent, index := splitID(id)
+		list := m.itemsAt(parent)
+		*list = append(*list, nil)
+		copy((*list)[index+2:], (*list)[index+1:])
+		(*list)[index+1] = item
+		m.changed(parent + "/" + strconv.Itoa(index+1))
+		return
+	} else if it != nil && it.Separator {
+		return
+	}
+
+	list := m.itemsAt(id)
+	*list = append(*list, item)
+	m.changed(id + "/" + strconv.Itoa(len(*list)-1))
+}
+
+func (m *MenuBuilder) changed(sel widget.TreeNodeID) {
+	m.last = m.encode()
+	m.tree.Refresh()
+	m.tree.OpenAllBranches()
+	if sel != "" {
+		m.tree.Select(sel)
+	}
+	m.choose(m.selected)
+
+	if m.OnChanged != nil {
+		m.OnChanged()
+	}
+}
+
+func (m *MenuBuilder) childIDs(id widget.TreeNodeID) []widget.TreeNodeID {
+	var count int
+	switch {
+	case id == "":
+		return []widget.TreeNodeID{menuMainID, menuTrayID}
+	case id == menuMainID:
+		count = len(m.design.Main)
+	default:
+		if list := m.itemsAt(id); list != nil {
+			count = len(*list)
+		}
+	}
+
+	ids := make([]widget.TreeNodeID, count)
+	for i := range ids {
+		ids[i] = id + "/" + strconv.Itoa(i)
+	}
+	return ids
+}
+
+func (m *MenuBuilder) choose(id widget.TreeNodeID) {
+	m.selected = id
+	if m.properties == nil {
+		return
+	}
+
+	form := widget.NewForm()
+	if menu := m.menuAt(id); menu != nil {
+		label := widget.NewEntry()
+		label.SetText(menu.Label)
+		label.OnChanged = func(s string) {
+			menu.Label = s
+			m.edited()
+		}
+		form.Append("Menu", label)
+	} else if it := m.itemAt(id); it != nil && it.Separator {
+		form.Append("Separator", widget.NewLabel("Separators have no properties"))
+	} else if it != nil {
+		m.itemForm(form, it)
+	} else {
+		form.Append("", widget.NewLabel("Select a menu or item to edit it"))
+	}
+
+	m.properties.Objects = []fyne.CanvasObject{form}
+	m.properties.Refresh()
+}
+
+func (m *MenuBuilder) edited() {
+	m.last = m.encode()
+	m.tree.RefreshItem(m.selected)
+
+	if m.OnChanged != nil {
+		m.OnChanged()
+	}
+}
+
+func (m *MenuBuilder) encode() []byte {
+	var buf bytes.Buffer
+	_ = gui.EncodeMenu(m.design, &buf)
+	return buf.Bytes()
+}
+
+func (m *MenuBuilder) itemForm(form *widget.Form, it *gui.MenuDesignItem) {
+	label := widget.NewEntry()
+	label.SetText(it.Label)
+	label.OnChanged = func(s string) {
+		it.Label = s
+		m.edited()
+	}
+
+	icon := guidefs.NewIconSelector(it.Icon, func(name string) {
+		it.Icon = name
+		m.edited()
+	})
+
+	shortcut := widget.NewEntry()
+	shortcut.SetPlaceHolder("Ctrl+Shift+S")
+	shortcut.SetText(it.Shortcut)
+	shortcut.Validator = func(s string) error {
+		_, err := gui.ParseShortcut(s)
+		return err
+	}
+	shortcut.OnChanged = func(s string) {
+		if _, err := gui.ParseShortcut(s); err != nil {
+			return
+		}
+		it.Shortcut = s
+		m.edited()
+	}
+
+	action := widget.NewEntry()
+	action.SetPlaceHolder("method name")
+	action.SetText(it.Action)
+	action.Validator = guidefs.ValidateIdentifier
+	action.OnChanged = func(s string) {
+		if action.Validate() != nil {
+			return
+		}
+		it.Action = s
+		m.edited()
+	}
+
+	checked := widget.NewCheck("", func(on bool) {
+		it.Checked = on
+		m.edited()
+	})
+	checked.Checked = it.Checked
+	disabled := widget.NewCheck("", func(on bool) {
+		it.Disabled = on
+		m.edited()
+	})
+	disabled.Checked = it.Disabled
+
+	form.Append("Label", label)
+	form.Append("Icon", icon)
+	form.Append("Shortcut", shortcut)
+	form.Append("Action", action)
+	form.Append("Checked", checked)
+	form.Append("Disabled", disabled)
+}
+
+// itemAt returns the menu item for a tree node, or nil if it is not an item.
+func (m *MenuBuilder) itemAt(id widget.TreeNodeID) *gui.MenuDesignItem {
+	parent, index := splitID(id)
+	if index < 0 || parent == menuMainID {
+		return nil
+	}
+
+	list := m.itemsAt(parent)
+	if list == nil || index >= len(*list) {
+		return nil
+	}
+	return (*list)[index]
+}
+
+// itemsAt returns the list of items shown under a tree node, or nil if it cannot have items.
+func (m *MenuBuilder) itemsAt(id widget.TreeNodeID) *[]*gui.MenuDesignItem {
+	if id == menuTrayID {
+		return &m.design.Tray
+	}
+	if menu := m.menuAt(id); menu != nil {
+		return &menu.Items
+	}
+	if it := m.itemAt(id); it != nil && !it.Separator {
+		return &it.Items
+	}
+	return nil
+}
+
+// menuAt returns the top level menu for a tree node, or nil if it is not a menu.
+func (m *MenuBuilder) menuAt(id widget.TreeNodeID) *gui.MenuDesignMenu {
+	parent, index := splitID(id)
+	if parent != menuMainID || index < 0 || index >= len(m.design.Main) {
+		return nil
+	}
+	return m.design.Main[index]
+}
+
+func (m *MenuBuilder) moveBy(delta int) {
+	parent, index := splitID(m.selected)
+	if index < 0 {
+		return
+	}
+
+	to := index + delta
+	if parent == menuMainID {
+		if to < 0 || to >= len(m.design.Main) {
+			return
+		}
+		m.design.Main[index], m.design.Main[to] = m.design.Main[to], m.design.Main[index]
+	} else {
+		list := m.itemsAt(parent)
+		if list == nil || to < 0 || to >= len(*list) {
+			return
+		}
+		(*list)[index], (*list)[to] = (*list)[to], (*list)[index]
+	}
+	m.tree.Unselect(m.selected)
+	m.changed(parent + "/" + strconv.Itoa(to))
+}
+
+func (m *MenuBuilder) nodeLabel(id widget.TreeNodeID) (string, fyne.Resource) {
+	switch id {
+	case menuMainID:
+		return "Main Menu", theme.MenuIcon()
+	case menuTrayID:
+		return "Tray Menu", theme.ComputerIcon()
+	}
+
+	if menu := m.menuAt(id); menu != nil {
+		return menu.Label, theme.ListIcon()
+	}
+	it := m.itemAt(id)
+	if it == nil {
+		return "", nil
+	}
+	if it.Separator {
+		return "────────", nil
+	}
+
+	text := it.Label
+	if it.Shortcut != "" {
+		text += "  (" + it.Shortcut + ")"
+	}
+	if it.Action != "" {
+		text += "  → " + it.Action
+	}
+	return text, guidefs.Icons[it.Icon]
+}
+
+func (m *MenuBuilder) remove() {
+	parent, index := splitID(m.selected)
+	if index < 0 {
+		return
+	}
+
+	if parent == menuMainID {
+		m.design.Main = append(m.design.Main[:index], m.design.Main[index+1:]...)
+	} else if list := m.itemsAt(parent); list != nil {
+		*list = append((*list)[:index], (*list)[index+1:]...)
+	}
+	m.tree.UnselectAll()
+	m.selected = ""
+	m.changed("")
+}
+
+// splitID returns the parent of a tree node and its index within it, or -1 if the node is not in a list.
+func splitID(id widget.TreeNodeID) (widget.TreeNodeID, int) {
+	pos := strings.LastIndex(id, "/")
+	if pos < 0 {
+		return "", -1
+	}
+
+	index, err := strconv.Atoi(id[pos+1:])
+	if err != nil {
+		return "", -1
+	}
+	return id[:pos], index
+}
@@ -0,0 +1,193 @@
+package guibuilder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+// rootID is the tree node ID of the design root, child nodes append "/" and their index.
+const rootID = "/"
+
+func idForPath(path []int) widget.TreeNodeID {
+	if len(path) == 0 {
+		return rootID
+	}
+
+	str := &strings.Builder{}
+	for _, i := range path {
+		str.WriteString("/" + strconv.Itoa(i))
+	}
+	return str.String()
+}
+
+func pathForID(id widget.TreeNodeID) []int {
+	var path []int
+	for _, elem := range strings.Split(strings.Trim(id, "/"), "/") {
+		if elem == "" {
+			continue
+		}
+
+		i, err := strconv.Atoi(elem)
+		if err != nil {
+			return nil
+		}
+		path = append(path, i)
+	}
+	return path
+}
+
+// buildOutline returns the hierarchy panel listing every object in the design.
+func (b *Builder) buildOutline() fyne.CanvasObject {
+	b.outline = widget.NewTree(
+		func(id widget.TreeNodeID) []widget.TreeNodeID {
+			if id == "" {
+				return []widget.TreeNodeID{rootID}
+			}
+
+			path := pathForID(id)
+			kids := children(objectAt(b.root, path))
+			ids := make([]widget.TreeNodeID, len(kids))
+			for i := range kids {
+				ids[i] = idForPath(append(append([]int{}, path...), i))
+			}
+			return ids
+		},
+		func(id widget.TreeNodeID) bool {
+			if id == "" {
+				return true
+			}
+
+			o := objectAt(b.root, pathForID(id))
+			return o != nil && isContainer(o)
+		},
+		func(bool) fyne.CanvasObject {
+			return widget.NewLabel("Template Object")
+		},
+		func(id widget.TreeNodeID, _ bool, item fyne.CanvasObject) {
+			item.(*widget.Label).SetText(b.outlineLabel(objectAt(b.root, pathForID(id))))
+		})
+	b.outline.OnSelected = func(id widget.TreeNodeID) {
+		o := objectAt(b.root, pathForID(id))
+		if o == nil || o == b.current {
+			return
+		}
+
+		b.choose(o)
+	}
+	b.outline.OpenBranch(rootID)
+
+	tools := widget.NewToolbar(
+		widget.NewToolbarAction(theme.DocumentCreateIcon(), func() {
+			b.showRename(b.current)
+		}),
+		widget.NewToolbarAction(theme.MoveUpIcon(), func() {
+			b.moveBy(b.current, -1)
+		}),
+		widget.NewToolbarAction(theme.MoveDownIcon(), func() {
+			b.moveBy(b.current, 1)
+		}),
+		widget.NewToolbarSeparator(),
+		widget.NewToolbarAction(theme.ContentCutIcon(), b.Cut),
+		widget.NewToolbarAction(theme.ContentCopyIcon(), b.Copy),
+		widget.NewToolbarAction(theme.ContentPasteIcon(), b.Paste),
+		widget.NewToolbarAction(theme.ContentAddIcon(), b.Duplicate),
+		widget.NewToolbarSeparator(),
+		widget.NewToolbarAction(theme.DeleteIcon(), func() {
+			b.remove(b.current)
+		}),
+	)
+	return container.NewBorder(tools, nil, nil, nil, b.outline)
+}
+
+func (b *Builder) outlineLabel(o fyne.CanvasObject) string {
+	if o == nil {
+		return "(Empty)"
+	}
+
+	label := gui.NameOf(o)
+	if name := b.meta[o]["name"]; name != "" {
+		label = fmt.Sprintf("%s (%s)", label, name)
+	}
+	if !o.Visible() {
+		label += " [hidden]"
+	}
+	return label
+}
+
+// selectInOutline highlights the object in the outline, opening the branches that lead to it.
+func (b *Builder) selectInOutline(o fyne.CanvasObject) {
+	if b.outline == nil {
+		return
+	}
+
+	path := pathTo(b.root, o)
+	if path == nil {
+		b.outline.UnselectAll()
+		return
+	}
+	for i := range path {
+		b.outline.OpenBranch(idForPath(path[:i]))
+	}
+	id := idForPath(path)
+	b.outline.Select(id)
+	b.outline.ScrollTo(id)
+}
+
+// moveBy moves an object forward or backward amongst its siblings.
+func (b *Builder) moveBy(o fyne.CanvasObject, delta int) {
+	parent, ok := parentOf(b.root, o).(*fyne.Container)
+	if !ok {
+		return
+	}
+
+	from := indexIn(parent, o)
+	to := from + delta
+	if to < 0 || to >= len(parent.Objects) {
+		return
+	}
+
+	b.reorder(parent, from, to)
+	b.choose(o)
+	b.recordChange(structureChange, parent)
+}
+
+// remove deletes an object from the design and selects its parent.
+func (b *Builder) remove(o fyne.CanvasObject) {
+	parent := b.detach(o)
+	if parent == nil {
+		return
+	}
+
+	b.choose(parent)
+	b.recordChange(structureChange, parent)
+}
+
+func (b *Builder) showRename(o fyne.CanvasObject) {
+	if o == nil {
+		return
+	}
+
+	name := widget.NewEntry()
+	name.SetText(b.meta[o]["name"])
+	name.Validator = guidefs.ValidateIdentifier
+	dialog.ShowForm("Rename "+gui.NameOf(o), "Rename", "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("Variable", name)},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+
+			b.choose(o)
+			b.widName.SetText(name.Text)
+		}, b.win)
+}
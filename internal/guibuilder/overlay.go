@@ -1,12 +1,13 @@
 package guibuilder
 
 import (
+	"fmt"
 	"image/color"
 	"reflect"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
@@ -19,6 +20,23 @@ type overlay struct {
 
 	b         *Builder
 	indicator *canvas.Rectangle
+	padding   *canvas.Rectangle
+	size      *canvas.Text
+	hover     *canvas.Rectangle
+
+	// the last position and size of the selected object, so we only move the indicator when it changes
+	selPos  fyne.Position
+	selSize fyne.Size
+
+	drop     *canvas.Rectangle
+	dragging bool
+	moving   fyne.CanvasObject
+	target   *dropTarget
+
+	// moving or resizing a child of a "Free" container, with guides showing the edges it snapped to
+	free           *freeDrag
+	handle         *canvas.Rectangle
+	guideX, guideY *canvas.Line
 }
 
 func newOverlay(b *Builder) *overlay {
@@ -28,32 +46,287 @@ func newOverlay(b *Builder) *overlay {
 }
 
 func (o *overlay) CreateRenderer() fyne.WidgetRenderer {
-	r := canvas.NewRectangle(color.Transparent)
-	r.StrokeColor = color.Transparent
-	r.StrokeWidth = 4
-	r.Resize(fyne.NewSize(20, 10))
-	r.Move(fyne.NewPos(10, 10))
+	o.indicator = canvas.NewRectangle(color.Transparent)
+	o.indicator.StrokeWidth = 4
+	o.indicator.Hide()
+	o.padding = canvas.NewRectangle(color.Transparent)
+	o.padding.Hide()
+	o.size = canvas.NewText("", color.Transparent)
+	o.size.Hide()
+	o.hover = canvas.NewRectangle(color.Transparent)
+	o.hover.StrokeWidth = 1
+	o.hover.Hide()
+
+	o.drop = canvas.NewRectangle(color.Transparent)
+	o.drop.StrokeWidth = 2
+	o.drop.Hide()
+
+	o.handle = canvas.NewRectangle(color.Transparent)
+	o.handle.Resize(fyne.NewSize(freeHandleSize, freeHandleSize))
+	o.handle.Hide()
+	o.guideX = canvas.NewLine(color.Transparent)
+	o.guideX.Hide()
+	o.guideY = canvas.NewLine(color.Transparent)
+	o.guideY.Hide()
+
+	r := &overlayRenderer{o: o, objects: []fyne.CanvasObject{o.padding, o.hover, o.indicator, o.size, o.drop, o.handle,
+		o.guideX, o.guideY}}
+	r.applyTheme()
+	return r
+}
+
+func (o *overlay) MouseIn(ev *desktop.MouseEvent) {
+	o.MouseMoved(ev)
+}
+
+// MouseMoved highlights the object that would be selected by a tap at the mouse position.
+func (o *overlay) MouseMoved(ev *desktop.MouseEvent) {
+	if o.hover == nil || o.dragging {
+		return
+	}
+
+	obj := findObject(o.b.root, ev.Position)
+	if obj == nil || obj == o.b.current {
+		o.hover.Hide()
+		return
+	}
+
+	o.hover.Move(o.b.absPos(obj))
+	o.hover.Resize(obj.Size())
+	o.hover.Show()
+	o.hover.Refresh()
+}
+
+func (o *overlay) MouseOut() {
+	if o.hover != nil {
+		o.hover.Hide()
+	}
+}
+
+func (o *overlay) Dragged(ev *fyne.DragEvent) {
+	if !o.dragging {
+		o.dragging = true
+		start := ev.Position.Subtract(ev.Dragged)
+		if o.free = o.b.startFreeDrag(start); o.free == nil {
+			obj := findObject(o.b.root, start)
+			if obj != o.b.root {
+				o.moving = obj
+			}
+		} else {
+			o.b.choose(o.free.obj)
+		}
+	}
+	if o.free != nil {
+		if !o.b.leaveFree(o.free, ev.Position) {
+			o.dragFree(ev.Dragged)
+			return
+		}
+
+		// dragged out of the "Free" container, so move the object to wherever it is dropped
+		o.moving, o.free = o.free.obj, nil
+		o.guideX.Hide()
+		o.guideY.Hide()
+		o.track()
+	}
+	if o.moving == nil {
+		return
+	}
+
+	o.dragOver(ev.AbsolutePosition, o.moving)
+}
+
+func (o *overlay) DragEnd() {
+	moving, free := o.moving, o.free
+	o.dragging = false
+	o.moving, o.free = nil, nil
+	if free != nil {
+		o.guideX.Hide()
+		o.guideY.Hide()
+		o.b.choose(free.obj)
+		o.b.recordChange(editChange, free.obj)
+		return
+	}
 
-	o.indicator = r
+	if t := o.endDrag(); moving != nil && t != nil {
+		o.b.move(moving, t)
+	}
+}
+
+// dragOver shows where an object would be dropped, for a drag at the given absolute position.
+func (o *overlay) dragOver(abs fyne.Position, moving fyne.CanvasObject) {
+	if o.drop == nil {
+		return
+	}
+
+	p := abs.Subtract(fyne.CurrentApp().Driver().AbsolutePositionForObject(o.b.root))
+	o.target = o.b.findDropTarget(p, moving)
+	if o.target == nil {
+		o.drop.Hide()
+		return
+	}
+
+	o.drop.Move(o.target.pos)
+	o.drop.Resize(o.target.size)
+	o.drop.Show()
+	o.drop.Refresh()
+}
 
-	return widget.NewSimpleRenderer(container.NewWithoutLayout(r))
+// dragFree moves or resizes the child of a "Free" container being dragged, showing the edges that it snapped to.
+func (o *overlay) dragFree(d fyne.Delta) {
+	guideX, guideY := o.b.dragFree(o.free, d)
+	o.track()
+	origin, size := o.b.absPos(o.free.parent), o.free.parent.Size()
+	if guideX >= 0 {
+		o.guideX.Position1 = origin.AddXY(guideX, 0)
+		o.guideX.Position2 = origin.AddXY(guideX, size.Height)
+		o.guideX.Show()
+	} else {
+		o.guideX.Hide()
+	}
+	if guideY >= 0 {
+		o.guideY.Position1 = origin.AddXY(0, guideY)
+		o.guideY.Position2 = origin.AddXY(size.Width, guideY)
+		o.guideY.Show()
+	} else {
+		o.guideY.Hide()
+	}
+	o.guideX.Refresh()
+	o.guideY.Refresh()
+}
+
+// endDrag hides the drop indicator and returns the last target, or nil if there is none.
+func (o *overlay) endDrag() *dropTarget {
+	t := o.target
+	o.target = nil
+	if o.drop != nil {
+		o.drop.Hide()
+	}
+	return t
 }
 
 func (o *overlay) Tapped(pe *fyne.PointEvent) {
 	rootPos := fyne.CurrentApp().Driver().AbsolutePositionForObject(o.b.root)
 	pos := pe.AbsolutePosition.Subtract(rootPos)
 	obj := findObject(o.b.root, pos)
-
-	// TODO update when an item is removed, inserted, or if the UI resizes
-	o.indicator.StrokeColor = theme.Color(theme.ColorNamePrimary)
-	objAbsPos := fyne.CurrentApp().Driver().AbsolutePositionForObject(obj)
-	objPos := objAbsPos.Subtract(rootPos)
-	o.indicator.Move(objPos)
-	o.indicator.Resize(obj.Size())
+	if obj == nil {
+		return
+	}
 
 	o.b.choose(obj)
 }
 
+// highlight moves the selection indicator to surround the object.
+// It is called whenever the selection, or the layout of the design, changes.
+func (o *overlay) highlight(obj fyne.CanvasObject) {
+	if o.indicator == nil {
+		return
+	}
+
+	o.selSize = fyne.Size{} // force the guides to be updated
+	if obj != nil {
+		o.hover.Hide()
+	}
+	o.track()
+}
+
+// track moves the selection indicator and guides to match the current position and size of the selected object.
+func (o *overlay) track() {
+	obj := o.b.current
+	if o.indicator == nil {
+		return
+	}
+	if obj == nil || !obj.Visible() {
+		o.indicator.Hide()
+		o.padding.Hide()
+		o.size.Hide()
+		o.handle.Hide()
+		return
+	}
+
+	pos, size := o.b.absPos(obj), obj.Size()
+	if pos == o.selPos && size == o.selSize && o.indicator.Visible() {
+		return
+	}
+	o.selPos, o.selSize = pos, size
+
+	o.indicator.Move(pos)
+	o.indicator.Resize(size)
+	o.indicator.Show()
+
+	// the padding that layouts place around an object
+	pad := o.Theme().Size(theme.SizeNamePadding)
+	o.padding.StrokeWidth = pad
+	o.padding.Move(pos.SubtractXY(pad, pad))
+	o.padding.Resize(size.AddWidthHeight(pad*2, pad*2))
+	o.padding.Show()
+
+	o.size.Text = fmt.Sprintf("%.0f × %.0f", size.Width, size.Height)
+	labelPos := pos.AddXY(0, size.Height+pad)
+	if labelPos.Y+o.size.MinSize().Height > o.Size().Height {
+		labelPos = pos.SubtractXY(0, o.size.MinSize().Height+pad)
+	}
+	o.size.Move(labelPos)
+	o.size.Resize(o.size.MinSize())
+	o.size.Show()
+
+	// children of a "Free" container can be resized from the bottom right corner
+	if o.b.freeParent(obj) != nil {
+		o.handle.Move(pos.Add(size).SubtractXY(freeHandleSize, freeHandleSize))
+		o.handle.Show()
+	} else {
+		o.handle.Hide()
+	}
+
+	o.indicator.Refresh()
+	o.padding.Refresh()
+	o.size.Refresh()
+	o.handle.Refresh()
+}
+
+type overlayRenderer struct {
+	o       *overlay
+	objects []fyne.CanvasObject
+}
+
+func (r *overlayRenderer) Destroy() {
+}
+
+func (r *overlayRenderer) Layout(fyne.Size) {
+	r.o.highlight(r.o.b.current)
+}
+
+func (r *overlayRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+func (r *overlayRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *overlayRenderer) Refresh() {
+	r.applyTheme()
+	for _, o := range r.objects {
+		o.Refresh()
+	}
+}
+
+func (r *overlayRenderer) applyTheme() {
+	th := r.o.Theme()
+	v := fyne.CurrentApp().Settings().ThemeVariant()
+
+	r.o.indicator.StrokeColor = th.Color(theme.ColorNamePrimary, v)
+	r.o.padding.StrokeColor = th.Color(theme.ColorNameSelection, v)
+	r.o.size.Color = th.Color(theme.ColorNamePrimary, v)
+	r.o.size.TextSize = th.Size(theme.SizeNameCaptionText)
+	r.o.hover.FillColor = th.Color(theme.ColorNameHover, v)
+	r.o.hover.StrokeColor = th.Color(theme.ColorNameSelection, v)
+	r.o.drop.FillColor = th.Color(theme.ColorNameSelection, v)
+	r.o.drop.StrokeColor = th.Color(theme.ColorNamePrimary, v)
+	r.o.handle.FillColor = th.Color(theme.ColorNamePrimary, v)
+	r.o.guideX.StrokeColor = th.Color(theme.ColorNameFocus, v)
+	r.o.guideY.StrokeColor = th.Color(theme.ColorNameFocus, v)
+}
+
 func findObject(o fyne.CanvasObject, p fyne.Position) fyne.CanvasObject {
 	switch w := o.(type) {
 	case *fyne.Container:
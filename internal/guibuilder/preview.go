@@ -0,0 +1,384 @@
+package guibuilder
+
+import (
+	"bytes"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+const (
+	customDevice  = "Custom"
+	defaultTheme  = "Default"
+	systemVariant = "System"
+	// themeFileSuffix identifies Fyne JSON theme files in the project that can be used for previews.
+	themeFileSuffix = "theme.json"
+)
+
+// device is a named screen size that a design can be previewed at.
+// A zero size means that the design fills the editor.
+type device struct {
+	name string
+	size fyne.Size
+}
+
+var devices = []device{
+	{name: "Fill Editor"},
+	{name: "Phone Portrait", size: fyne.NewSize(360, 640)},
+	{name: "Phone Landscape", size: fyne.NewSize(640, 360)},
+	{name: "Tablet Portrait", size: fyne.NewSize(768, 1024)},
+	{name: "Tablet Landscape", size: fyne.NewSize(1024, 768)},
+	{name: "Desktop", size: fyne.NewSize(1280, 800)},
+	{name: "Desktop HD", size: fyne.NewSize(1920, 1080)},
+}
+
+var previewScales = []string{"50%", "75%", "100%", "125%", "150%", "200%"}
+
+// previewTheme wraps a theme to force a light or dark variant and scale all sizes.
+type previewTheme struct {
+	fyne.Theme
+
+	variant fyne.ThemeVariant
+	forced  bool
+	scale   float32
+}
+
+func newPreviewTheme(base fyne.Theme, variant string, scale float32) *previewTheme {
+	if base == nil {
+		base = theme.DefaultTheme()
+	}
+
+	t := &previewTheme{Theme: base, scale: scale}
+	switch variant {
+	case "Light":
+		t.variant, t.forced = theme.VariantLight, true
+	case "Dark":
+		t.variant, t.forced = theme.VariantDark, true
+	}
+	return t
+}
+
+func (t *previewTheme) Color(n fyne.ThemeColorName, v fyne.ThemeVariant) color.Color {
+	if t.forced {
+		v = t.variant
+	}
+	return t.Theme.Color(n, v)
+}
+
+func (t *previewTheme) Size(n fyne.ThemeSizeName) float32 {
+	return t.Theme.Size(n) * t.scale
+}
+
+// Variant returns the variant that the preview shows, which is the app variant unless one was chosen.
+// Theme colours in the design use this to match the preview.
+func (t *previewTheme) Variant() fyne.ThemeVariant {
+	if !t.forced {
+		return fyne.CurrentApp().Settings().ThemeVariant()
+	}
+	return t.variant
+}
+
+// background returns the colour that a window using this theme would be filled with.
+func (t *previewTheme) background() color.Color {
+	return t.Color(theme.ColorNameBackground, t.Variant())
+}
+
+// deviceLayout places a single object at the scaled size of a device, or fills the space if the size is zero.
+type deviceLayout struct {
+	size  fyne.Size
+	scale float32
+}
+
+func (d *deviceLayout) Layout(objs []fyne.CanvasObject, size fyne.Size) {
+	pos := fyne.NewPos(0, 0)
+	if !d.size.IsZero() {
+		want := d.MinSize(objs)
+		pos = fyne.NewPos((size.Width-want.Width)/2, (size.Height-want.Height)/2)
+		size = want
+	}
+
+	for _, o := range objs {
+		o.Move(pos)
+		o.Resize(size)
+	}
+}
+
+func (d *deviceLayout) MinSize(objs []fyne.CanvasObject) fyne.Size {
+	if d.size.IsZero() {
+		minSize := fyne.NewSize(0, 0)
+		for _, o := range objs {
+			minSize = minSize.Max(o.MinSize())
+		}
+		return minSize
+	}
+
+	return fyne.NewSize(d.size.Width*d.scale, d.size.Height*d.scale)
+}
+
+// preview holds the settings of the design surface, which emulates a device with a theme applied.
+type preview struct {
+	b *Builder
+
+	layout  *deviceLayout
+	frame   *fyne.Container
+	themed  *container.ThemeOverride
+	bg      *canvas.Rectangle
+	variant string
+	th      fyne.Theme // the project theme, or nil to use the default
+}
+
+// buildSurface returns the design surface, wrapping the design in a device frame, and its toolbar.
+func (b *Builder) buildSurface() fyne.CanvasObject {
+	p := &preview{b: b, layout: &deviceLayout{scale: 1}, variant: systemVariant}
+	p.bg = canvas.NewRectangle(color.Transparent)
+	p.themed = container.NewThemeOverride(container.NewStack(p.bg, b.design), newPreviewTheme(nil, p.variant, 1))
+	p.frame = container.New(p.layout, p.themed)
+	p.apply()
+
+	return container.NewBorder(p.makeToolbar(), nil, nil, nil, container.NewScroll(p.frame))
+}
+
+func (p *preview) makeToolbar() fyne.CanvasObject {
+	width, height := widget.NewEntry(), widget.NewEntry()
+	width.SetPlaceHolder("Width")
+	height.SetPlaceHolder("Height")
+	custom := container.NewGridWithColumns(2, width, height)
+	custom.Hide()
+	applyCustom := func(string) {
+		w, errW := strconv.ParseFloat(width.Text, 32)
+		h, errH := strconv.ParseFloat(height.Text, 32)
+		if errW != nil || errH != nil || w <= 0 || h <= 0 {
+			return
+		}
+
+		p.layout.size = fyne.NewSize(float32(w), float32(h))
+		p.apply()
+	}
+	width.OnChanged = applyCustom
+	height.OnChanged = applyCustom
+
+	names := make([]string, len(devices)+1)
+	for i, d := range devices {
+		names[i] = d.name
+	}
+	names[len(devices)] = customDevice
+	size := widget.NewSelect(names, func(name string) {
+		if name == customDevice {
+			custom.Show()
+			applyCustom("")
+			return
+		}
+
+		custom.Hide()
+		for _, d := range devices {
+			if d.name == name {
+				p.layout.size = d.size
+			}
+		}
+		p.apply()
+	})
+	size.SetSelected(devices[0].name)
+
+	variant := widget.NewSelect([]string{systemVariant, "Light", "Dark"}, func(v string) {
+		p.variant = v
+		p.apply()
+	})
+	variant.SetSelected(systemVariant)
+
+	scale := widget.NewSelect(previewScales, func(s string) {
+		f, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 32)
+		if err != nil {
+			return
+		}
+
+		p.layout.scale = float32(f / 100)
+		p.apply()
+	})
+	scale.SetSelected("100%")
+
+	themes := widget.NewSelect(append([]string{defaultTheme}, p.b.projectThemes()...), func(name string) {
+		p.th = nil
+		if name != defaultTheme {
+			p.th = p.b.loadTheme(name)
+		}
+		p.apply()
+	})
+	themes.SetSelected(defaultTheme)
+
+	settings := widget.NewButtonWithIcon("Design...", theme.SettingsIcon(), p.b.showDesignSettings)
+	keys := widget.NewButtonWithIcon("Keyboard...", theme.ComputerIcon(), p.b.showKeyboardSettings)
+	styles := widget.NewButtonWithIcon("Styles...", theme.ColorPaletteIcon(), p.b.showStyles)
+	strs := widget.NewButtonWithIcon("Strings...", theme.DocumentIcon(), p.b.showStrings)
+	export := widget.NewButtonWithIcon("Export Image...", theme.DownloadIcon(), p.showExport)
+	return container.NewHBox(size, custom, variant, scale, themes, layout.NewSpacer(), settings, keys, styles, strs,
+		export)
+}
+
+// imageOptions returns the settings to render an image of the design as it is currently previewed.
+func (p *preview) imageOptions() gui.ImageOptions {
+	size := p.layout.size
+	if size.IsZero() && p.b.design != nil {
+		design := p.b.design.Size()
+		size = fyne.NewSize(design.Width/p.layout.scale, design.Height/p.layout.scale)
+	}
+
+	variant := fyne.CurrentApp().Settings().ThemeVariant()
+	switch p.variant {
+	case "Light":
+		variant = theme.VariantLight
+	case "Dark":
+		variant = theme.VariantDark
+	}
+	return gui.ImageOptions{Size: size, Scale: p.layout.scale, Theme: p.th, Variant: variant}
+}
+
+// showExport asks where to save an image of the design, the format is chosen by the file extension.
+func (p *preview) showExport() {
+	opts := p.imageOptions()
+	save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, p.b.win)
+			return
+		}
+		if w == nil {
+			return
+		}
+
+		err = p.b.exportImage(w, opts)
+		_ = w.Close()
+		if err != nil {
+			dialog.ShowError(err, p.b.win)
+		}
+	}, p.b.win)
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".png", ".svg"}))
+	save.SetFileName(strings.TrimSuffix(p.b.uri.Name(), ".gui.json") + ".png")
+	if dir, err := storage.ListerForURI(p.b.ProjectRoot()); err == nil {
+		save.SetLocation(dir)
+	}
+	save.Show()
+}
+
+// imageContext loads a copy of the design to export, drawing theme colours with the theme of the image.
+type imageContext struct {
+	*Builder
+
+	th fyne.Theme
+}
+
+func (c *imageContext) Theme() fyne.Theme {
+	return c.th
+}
+
+// exportImage renders a copy of the design, so that the editor is not disturbed, and writes it as PNG or SVG.
+// An SVG file embeds the PNG image.
+func (b *Builder) exportImage(w fyne.URIWriteCloser, opts gui.ImageOptions) error {
+	obj, _, err := gui.DecodeObject(bytes.NewReader(b.snapshot()), &imageContext{Builder: b, th: opts.RenderTheme()})
+	if err != nil {
+		return err
+	}
+	defer walk(obj, func(o fyne.CanvasObject) {
+		delete(b.meta, o)
+	})
+
+	if strings.EqualFold(w.URI().Extension(), ".svg") {
+		return gui.ExportSVG(obj, opts, w)
+	}
+	return gui.ExportPNG(obj, opts, w)
+}
+
+// apply updates the design surface to use the current preview settings.
+func (p *preview) apply() {
+	if p.themed == nil {
+		return
+	}
+
+	th := newPreviewTheme(p.th, p.variant, p.layout.scale)
+	p.b.th = th
+	p.bg.FillColor = th.background()
+	p.bg.Refresh()
+	p.themed.Theme = th
+	p.b.refreshTheme()
+	p.themed.Refresh()
+	p.frame.Refresh()
+
+	if p.b.overlay != nil {
+		p.b.overlay.highlight(p.b.current)
+	}
+}
+
+// refreshTheme redraws the design after the preview theme changed, so that theme colours and
+// theme overrides use the new theme and variant.
+func (b *Builder) refreshTheme() {
+	walk(b.root, func(o fyne.CanvasObject) {
+		if t, ok := o.(*container.ThemeOverride); ok {
+			data := b.meta[t]["data"]
+			if data == "" {
+				data = "{}"
+			}
+			if th, err := guidefs.ThemeFromJSON(data, b); err == nil {
+				t.Theme = th
+			}
+		}
+		o.Refresh()
+	})
+}
+
+// projectThemes lists the theme files found in the project root directory.
+func (b *Builder) projectThemes() []string {
+	root := b.ProjectRoot()
+	if root == nil {
+		return nil
+	}
+	items, err := storage.List(root)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, u := range items {
+		if strings.HasSuffix(u.Name(), themeFileSuffix) {
+			names = append(names, u.Name())
+		}
+	}
+	return names
+}
+
+// loadTheme parses a Fyne JSON theme file from the project root, or returns nil if it could not be loaded.
+func (b *Builder) loadTheme(name string) fyne.Theme {
+	u, err := storage.Child(b.ProjectRoot(), name)
+	if err != nil {
+		fyne.LogError("Failed to find theme "+name, err)
+		return nil
+	}
+	r, err := storage.Reader(u)
+	if err != nil {
+		fyne.LogError("Failed to open theme "+name, err)
+		return nil
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		fyne.LogError("Failed to read theme "+name, err)
+		return nil
+	}
+	th, err := theme.FromJSON(string(data))
+	if err != nil {
+		fyne.LogError("Failed to parse theme "+name, err)
+		return nil
+	}
+	return th
+}
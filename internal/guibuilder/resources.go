@@ -0,0 +1,92 @@
+package guibuilder
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+// resourcesGoFile is the name of the generated file, next to the designs, that bundles the images they show.
+const resourcesGoFile = "resources.defyne.go"
+
+// writeResourcesGo bundles the images and fonts that this design, and the saved designs in the same directory, use.
+// The file is only written if resources are used or it was generated before.
+func (b *Builder) writeResourcesGo(dir fyne.URI) error {
+	if b.ProjectRoot() == nil {
+		return nil
+	}
+
+	used := make(map[string]bool)
+	for _, p := range b.resourcesUsed() {
+		used[p] = true
+	}
+	items, _ := storage.List(dir)
+	for _, u := range items {
+		if !strings.HasSuffix(u.Name(), ".gui.json") || u.String() == b.uri.String() {
+			continue
+		}
+
+		r, err := storage.Reader(u)
+		if err != nil {
+			fyne.LogError("Failed to open design "+u.Name(), err)
+			continue
+		}
+		data, err := io.ReadAll(r)
+		_ = r.Close()
+		if err != nil {
+			fyne.LogError("Failed to open design "+u.Name(), err)
+			continue
+		}
+		images, err := gui.ImagesUsedInJSON(bytes.NewReader(data))
+		if err != nil {
+			fyne.LogError("Failed to read design "+u.Name(), err)
+			continue
+		}
+		fonts, _ := gui.FontsUsedInJSON(bytes.NewReader(data))
+		for _, p := range append(images, fonts...) {
+			used[p] = true
+		}
+	}
+
+	u, err := storage.Child(dir, resourcesGoFile)
+	if err != nil {
+		return err
+	}
+	if len(used) == 0 {
+		if ok, _ := storage.Exists(u); !ok {
+			return nil
+		}
+	}
+	paths := make([]string, 0, len(used))
+	for p := range used {
+		paths = append(paths, p)
+	}
+
+	w, err := storage.Writer(u)
+	if err != nil {
+		return err
+	}
+	err = gui.ExportResourcesGo(b, paths, w)
+	_ = w.Close()
+	return err
+}
+
+// resourcesUsed returns the paths of the project images and fonts that the design currently uses.
+func (b *Builder) resourcesUsed() []string {
+	live := b.liveMetadata()
+	return append(gui.ImagesUsed(live), gui.FontsUsed(live)...)
+}
+
+// liveMetadata returns the metadata of the objects that are currently in the design.
+func (b *Builder) liveMetadata() map[fyne.CanvasObject]map[string]string {
+	live := make(map[fyne.CanvasObject]map[string]string)
+	walk(b.root, func(o fyne.CanvasObject) {
+		live[o] = b.meta[o]
+	})
+	return live
+}
@@ -0,0 +1,304 @@
+package guibuilder
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+const (
+	// previewModule is the module name used when compiling a preview of the design.
+	previewModule = "defynepreview"
+	// previewFyneVersion is the Fyne version that previews use, outside of a project, if the build does not say.
+	previewFyneVersion = "v2.6.1-rc1.0.20250613172131-aaf88166a2a9"
+)
+
+// Run opens an interactive preview of the design in a new window.
+// Actions are not compiled, calling them will show the name of the handler instead.
+func (b *Builder) Run() {
+	obj, _, err := gui.DecodeObject(bytes.NewReader(b.snapshot()), b)
+	if err != nil {
+		dialog.ShowError(err, b.win)
+		return
+	}
+
+	status := widget.NewLabel("Interact with the design to test it, actions will be listed here")
+	b.stubActions(obj, func(msg string) {
+		status.SetText(msg)
+	})
+
+	props := b.meta[obj]
+	w := fyne.CurrentApp().NewWindow("Preview: " + b.uri.Name())
+	w.SetOnClosed(func() {
+		walk(obj, func(o fyne.CanvasObject) {
+			delete(b.meta, o)
+		})
+	})
+	size := obj.MinSize().Max(fyne.NewSize(320, 240))
+	if width, err := strconv.ParseFloat(props[gui.DesignWidth], 32); err == nil {
+		size.Width = float32(width)
+	}
+	if height, err := strconv.ParseFloat(props[gui.DesignHeight], 32); err == nil {
+		size.Height = float32(height)
+	}
+
+	switch props[gui.DesignKind] {
+	case gui.DesignDialog:
+		show := widget.NewButton("Show Dialog", nil)
+		show.OnTapped = func() {
+			dismiss := props[gui.DesignDismiss]
+			if dismiss == "" {
+				dismiss = "OK"
+			}
+			d := dialog.NewCustom(props[gui.DesignTitle], dismiss, obj, w)
+			if confirm := props[gui.DesignConfirm]; confirm != "" {
+				d = dialog.NewCustomConfirm(props[gui.DesignTitle], confirm, dismiss, obj, func(ok bool) {
+					status.SetText("Dialog closed, confirmed: " + strconv.FormatBool(ok))
+				}, w)
+			}
+			d.Resize(obj.MinSize().Max(size))
+			d.Show()
+		}
+		w.SetContent(container.NewBorder(nil, status, nil, nil, container.NewCenter(show)))
+		w.Resize(size.AddWidthHeight(160, 160))
+		w.Show()
+		show.OnTapped()
+		return
+	case gui.DesignWindow:
+		if title := props[gui.DesignTitle]; title != "" {
+			w.SetTitle(title)
+		}
+		w.SetFixedSize(props[gui.DesignFixed] == "true")
+	}
+
+	w.SetContent(container.NewBorder(nil, status, nil, nil, obj))
+	w.Resize(size)
+	w.Show()
+}
+
+// stubActions sets each callback that the design has an action for to a function reporting that it was called.
+func (b *Builder) stubActions(obj fyne.CanvasObject, called func(string)) {
+	walk(obj, func(o fyne.CanvasObject) {
+		v := reflect.ValueOf(o)
+		if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+			return
+		}
+
+		for field, action := range b.meta[o] {
+			if len(field) <= 2 || field[0:2] != "On" || action == "" {
+				continue
+			}
+			f := v.Elem().FieldByName(field)
+			if !f.IsValid() || f.Kind() != reflect.Func || !f.CanSet() {
+				continue
+			}
+
+			name, action := field, action
+			f.Set(reflect.MakeFunc(f.Type(), func(args []reflect.Value) []reflect.Value {
+				called(gui.NameOf(o) + " " + name + ": " + action)
+
+				out := make([]reflect.Value, f.Type().NumOut())
+				for i := range out {
+					out[i] = reflect.Zero(f.Type().Out(i))
+				}
+				return out
+			}))
+		}
+
+		if bar, ok := o.(*widget.Toolbar); ok {
+			for i, item := range bar.Items {
+				act, ok := item.(*widget.ToolbarAction)
+				action := b.meta[o][guidefs.ToolbarItemKey(i, guidefs.ToolbarItemAction)]
+				if !ok || action == "" {
+					continue
+				}
+
+				label := gui.NameOf(o) + " item " + strconv.Itoa(i+1) + ": " + action
+				act.OnActivated = func() {
+					called(label)
+				}
+			}
+		}
+	})
+}
+
+// RunCompiled generates a go main function and runs it so we can preview the UI in a real app.
+// It uses the dependencies of the project, so that the network is only needed if they are not yet downloaded.
+func (b *Builder) RunCompiled() {
+	dir, err := os.MkdirTemp("", "defyne-preview")
+	if err != nil {
+		dialog.ShowError(err, b.win)
+		return
+	}
+
+	err = b.writePreview(dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		dialog.ShowError(err, b.win)
+		return
+	}
+
+	go func() {
+		defer os.RemoveAll(dir)
+
+		// the preview only uses some of the project dependencies, and may need others such as Fyne
+		var out bytes.Buffer
+		cmd := exec.Command("go", "mod", "tidy")
+		cmd.Dir = dir
+		cmd.Stderr = &out
+		if err := cmd.Run(); err != nil {
+			b.showRunError(err, out.String())
+			return
+		}
+
+		out.Reset()
+		cmd = exec.Command("go", "run", ".")
+		cmd.Dir = dir
+		cmd.Stderr = &out
+		cmd.Stdout = os.Stdout
+		if err := cmd.Run(); err != nil {
+			b.showRunError(err, out.String())
+		}
+	}()
+}
+
+// writePreview creates a module in dir that runs the design, using the go.mod and go.sum of the project if found.
+func (b *Builder) writePreview(dir string) error {
+	f, err := os.Create(filepath.Join(dir, "main.go"))
+	if err != nil {
+		return err
+	}
+	err = gui.ExportGoPreview(b.root, b, f)
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+
+	// the design may use constants from the string table, which are generated as a separate file
+	f, err = os.Create(filepath.Join(dir, "strings.go"))
+	if err != nil {
+		return err
+	}
+	err = gui.ExportStringsGo(b, f)
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+
+	// images and fonts are bundled into the preview, as they are for saved designs
+	f, err = os.Create(filepath.Join(dir, "resources.go"))
+	if err != nil {
+		return err
+	}
+	err = gui.ExportResourcesGo(b, b.resourcesUsed(), f)
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+
+	mod := "module " + previewModule + "\n\nrequire fyne.io/fyne/v2 " + fyneVersion() + "\n"
+	if root := b.ProjectRoot(); root != nil {
+		if data, err := os.ReadFile(filepath.Join(root.Path(), "go.mod")); err == nil {
+			mod = absoluteReplaces(renameModule(string(data), previewModule), root.Path())
+			if sum, err := os.ReadFile(filepath.Join(root.Path(), "go.sum")); err == nil {
+				err = os.WriteFile(filepath.Join(dir, "go.sum"), sum, 0644)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return os.WriteFile(filepath.Join(dir, "go.mod"), []byte(mod), 0644)
+}
+
+func (b *Builder) showRunError(err error, output string) {
+	if output != "" {
+		err = errors.New(strings.TrimSpace(output))
+	}
+
+	fyne.Do(func() {
+		dialog.ShowError(err, b.win)
+	})
+}
+
+// fyneVersion returns the version of Fyne that this app was built with, for previews outside of a project.
+func fyneVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == "fyne.io/fyne/v2" && dep.Version != "" && dep.Version != "(devel)" {
+				return dep.Version
+			}
+		}
+	}
+	return previewFyneVersion
+}
+
+// absoluteReplaces changes the replace directives of a go.mod file that use a relative path, so that they point
+// into the root directory of the module when the file is copied elsewhere.
+func absoluteReplaces(mod, root string) string {
+	var out strings.Builder
+	inBlock := false
+	scan := bufio.NewScanner(strings.NewReader(mod))
+	for scan.Scan() {
+		line := scan.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "replace") && strings.HasSuffix(trimmed, "("):
+			inBlock = true
+		case inBlock && trimmed == ")":
+			inBlock = false
+		case inBlock || strings.HasPrefix(trimmed, "replace "):
+			line = absoluteReplace(line, root)
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.String()
+}
+
+// absoluteReplace returns a replace directive line with the target made absolute, if it is a relative path.
+func absoluteReplace(line, root string) string {
+	i := strings.Index(line, "=>")
+	if i < 0 {
+		return line
+	}
+	target := strings.Fields(line[i+2:])
+	if len(target) == 0 {
+		return line
+	}
+
+	path := target[0]
+	if path != "." && path != ".." && !strings.HasPrefix(path, "./") && !strings.HasPrefix(path, "../") {
+		return line
+	}
+	return line[:i] + "=> " + filepath.ToSlash(filepath.Join(root, path))
+}
+
+// renameModule replaces the module declaration of a go.mod file.
+func renameModule(mod, name string) string {
+	var out strings.Builder
+	scan := bufio.NewScanner(strings.NewReader(mod))
+	for scan.Scan() {
+		line := scan.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "module ") {
+			line = "module " + name
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.String()
+}
@@ -0,0 +1,34 @@
+package guibuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameModule(t *testing.T) {
+	mod := "module example.com/app\n\ngo 1.21\n\nrequire fyne.io/fyne/v2 v2.6.0\n"
+	assert.Equal(t, "module defynepreview\n\ngo 1.21\n\nrequire fyne.io/fyne/v2 v2.6.0\n",
+		renameModule(mod, previewModule))
+
+	assert.Equal(t, "// app\nmodule other\n", renameModule("// app\n  module example.com/app\n", "other"))
+}
+
+func TestAbsoluteReplaces(t *testing.T) {
+	mod := "module app\n\n" +
+		"replace example.com/lib => ../lib\n" +
+		"replace example.com/remote => example.com/fork v1.0.0\n" +
+		"replace (\n" +
+		"\texample.com/local v1.0.0 => ./local\n" +
+		"\texample.com/abs => /opt/abs\n" +
+		")\n"
+
+	assert.Equal(t, "module app\n\n"+
+		"replace example.com/lib => /home/user/lib\n"+
+		"replace example.com/remote => example.com/fork v1.0.0\n"+
+		"replace (\n"+
+		"\texample.com/local v1.0.0 => /home/user/app/local\n"+
+		"\texample.com/abs => /opt/abs\n"+
+		")\n",
+		absoluteReplaces(mod, "/home/user/app"))
+}
@@ -0,0 +1,249 @@
+package guibuilder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+// stringsGoFile is the name of the generated file, next to the designs, with constants for the string table.
+const stringsGoFile = "strings.defyne.go"
+
+// stringEntry is a row of the string table being edited.
+type stringEntry struct {
+	key, text string
+	unused    bool
+}
+
+// showStrings lets the user edit the string table of the project, that text properties can be linked to.
+// Applying the changes saves the table, updates this design and regenerates the string constants.
+// Keys that a design of the project is linked to cannot be removed or renamed.
+func (b *Builder) showStrings() {
+	var rows []*stringEntry
+	for _, k := range guidefs.StringKeys(b) {
+		text, _ := guidefs.StringValue(b, k)
+		rows = append(rows, &stringEntry{key: k, text: text})
+	}
+
+	table := container.NewVBox()
+	status := widget.NewLabel("")
+	var refresh func()
+	refresh = func() {
+		table.Objects = nil
+		for i, s := range rows {
+			index := i
+			table.Add(stringRow(s, func() {
+				rows = append(rows[:index], rows[index+1:]...)
+				refresh()
+			}))
+		}
+		table.Refresh()
+	}
+	refresh()
+
+	add := widget.NewButtonWithIcon("Add String", theme.ContentAddIcon(), func() {
+		rows = append(rows, &stringEntry{})
+		refresh()
+	})
+	var removeUnused *widget.Button
+	removeUnused = widget.NewButtonWithIcon("Remove Unused", theme.DeleteIcon(), func() {
+		var used []*stringEntry
+		for _, s := range rows {
+			if !s.unused {
+				used = append(used, s)
+			}
+		}
+		rows = used
+		removeUnused.Disable()
+		status.SetText("")
+		refresh()
+	})
+	removeUnused.Disable()
+	findUnused := widget.NewButtonWithIcon("Find Unused", theme.SearchIcon(), func() {
+		used := b.projectStringsUsed()
+		count := 0
+		for _, s := range rows {
+			s.unused = !used[s.key]
+			if s.unused {
+				count++
+			}
+		}
+		status.SetText(fmt.Sprintf("%d unused strings", count))
+		if count > 0 {
+			removeUnused.Enable()
+		} else {
+			removeUnused.Disable()
+		}
+		refresh()
+	})
+
+	buttons := container.NewHBox(add, findUnused, removeUnused, status)
+	d := dialog.NewCustomConfirm("Strings", "Apply", "Cancel",
+		container.NewBorder(nil, buttons, nil, nil, container.NewVScroll(table)), func(ok bool) {
+			if !ok {
+				return
+			}
+
+			strs := make(map[string]string)
+			for _, s := range rows {
+				key := strings.TrimSpace(s.key)
+				if key == "" {
+					continue
+				}
+				if _, dupe := strs[key]; dupe {
+					dialog.ShowError(fmt.Errorf("the key %q is used more than once", key), b.win)
+					return
+				}
+				strs[key] = s.text
+			}
+			var missing []string
+			for k := range b.projectStringsUsed() {
+				if _, ok := strs[k]; !ok && k != "" {
+					missing = append(missing, k)
+				}
+			}
+			if len(missing) > 0 {
+				sort.Strings(missing)
+				dialog.ShowError(fmt.Errorf("the strings %s are still used by designs, unlink them first",
+					strings.Join(missing, ", ")), b.win)
+				return
+			}
+			if err := guidefs.SaveProjectStrings(b, strs); err != nil {
+				dialog.ShowError(err, b.win)
+				return
+			}
+			if err := b.writeProjectStringsGo(); err != nil {
+				dialog.ShowError(err, b.win)
+			}
+			b.applyStrings()
+		}, b.win)
+	d.Resize(fyne.NewSize(560, 420))
+	d.Show()
+}
+
+// applyStrings updates the text of objects in this design that are linked to the string table.
+func (b *Builder) applyStrings() {
+	changed := false
+	walk(b.root, func(o fyne.CanvasObject) {
+		if guidefs.ApplyStrings(o, b) {
+			o.Refresh()
+			changed = true
+		}
+	})
+	if !changed {
+		return
+	}
+
+	b.recordChange(editChange, b.allObjects()...)
+	if b.current != nil {
+		b.choose(b.current)
+	}
+}
+
+// projectStringsUsed returns the string keys that this design, and the saved designs of the project, are linked to.
+func (b *Builder) projectStringsUsed() map[string]bool {
+	used := make(map[string]bool)
+	for _, k := range gui.StringsUsed(b.liveMetadata()) {
+		used[k] = true
+	}
+
+	for _, u := range b.projectDesigns() {
+		if u.String() == b.uri.String() {
+			continue
+		}
+
+		r, err := storage.Reader(u)
+		if err != nil {
+			fyne.LogError("Failed to open design "+u.Name(), err)
+			continue
+		}
+		keys, err := gui.StringsUsedInJSON(r)
+		_ = r.Close()
+		if err != nil {
+			fyne.LogError("Failed to read design "+u.Name(), err)
+			continue
+		}
+		for _, k := range keys {
+			used[k] = true
+		}
+	}
+	return used
+}
+
+// writeProjectStringsGo generates the constants for the string table next to each design in the project.
+func (b *Builder) writeProjectStringsGo() error {
+	done := make(map[string]bool)
+	for _, u := range append(b.projectDesigns(), b.uri) {
+		dir, err := storage.Parent(u)
+		if err != nil || done[dir.String()] {
+			continue
+		}
+
+		done[dir.String()] = true
+		if err = b.writeStringsGo(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeStringsGo generates the constants for the string table in the directory of a design, if the project has
+// strings or they were generated before.
+// Designs are generated in package main, so the constants are written next to them in the same package.
+func (b *Builder) writeStringsGo(dir fyne.URI) error {
+	if b.ProjectRoot() == nil {
+		return nil
+	}
+
+	u, err := storage.Child(dir, stringsGoFile)
+	if err != nil {
+		return err
+	}
+	if len(guidefs.StringKeys(b)) == 0 {
+		if ok, _ := storage.Exists(u); !ok {
+			return nil
+		}
+	}
+	w, err := storage.Writer(u)
+	if err != nil {
+		return err
+	}
+	err = gui.ExportStringsGo(b, w)
+	_ = w.Close()
+	return err
+}
+
+func stringRow(s *stringEntry, remove func()) fyne.CanvasObject {
+	key := widget.NewEntry()
+	key.SetPlaceHolder("key")
+	key.SetText(s.key)
+	key.Validator = guidefs.ValidateStringKey
+	key.OnChanged = func(text string) {
+		s.key = text
+	}
+
+	text := widget.NewEntry()
+	text.SetPlaceHolder("Text")
+	text.SetText(s.text)
+	text.OnChanged = func(t string) {
+		s.text = t
+	}
+
+	del := widget.NewButtonWithIcon("", theme.DeleteIcon(), remove)
+	right := container.NewHBox(del)
+	if s.unused {
+		right.Objects = []fyne.CanvasObject{widget.NewLabelWithStyle("unused", fyne.TextAlignLeading,
+			fyne.TextStyle{Italic: true}), del}
+	}
+	return container.NewBorder(nil, nil, nil, right, container.NewGridWithColumns(2, key, text))
+}
@@ -0,0 +1,254 @@
+package guibuilder
+
+import (
+	"reflect"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+var borderSlots = []string{"top", "bottom", "left", "right"}
+
+// isContainer returns true if the object can hold child objects in the design.
+func isContainer(o fyne.CanvasObject) bool {
+	if _, ok := o.(*fyne.Container); ok {
+		return true
+	}
+
+	info := guidefs.Lookup(reflect.TypeOf(o).String())
+	return info != nil && info.IsContainer()
+}
+
+// parentOf returns the container holding o within the tree starting at root, or nil if it was not found.
+func parentOf(root, o fyne.CanvasObject) fyne.CanvasObject {
+	for _, child := range children(root) {
+		if child == o {
+			return root
+		}
+		if child == nil {
+			continue
+		}
+		if found := parentOf(child, o); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// contains returns true if o is the same as, or is found inside, the object parent.
+func contains(parent, o fyne.CanvasObject) bool {
+	return parent == o || (parent != nil && pathTo(parent, o) != nil)
+}
+
+// detach removes o from its parent, leaving an empty placeholder if the parent has a fixed set of children.
+// It returns the parent, or nil if o is not in the design.
+func (b *Builder) detach(o fyne.CanvasObject) fyne.CanvasObject {
+	parent := parentOf(b.root, o)
+	switch p := parent.(type) {
+	case *fyne.Container:
+		for i, child := range p.Objects {
+			if child != o {
+				continue
+			}
+
+			p.Objects = append(p.Objects[:i], p.Objects[i+1:]...)
+			shiftBorderSlots(b.meta[p], i, -1)
+			b.relayout(p)
+			break
+		}
+	case *container.AppTabs:
+		for _, item := range p.Items {
+			if item.Content == o {
+				item.Content = container.NewStack()
+			}
+		}
+		p.Refresh()
+	case *container.Split:
+		if p.Leading == o {
+			p.Leading = container.NewStack()
+		} else {
+			p.Trailing = container.NewStack()
+		}
+		p.Refresh()
+	case *container.Scroll:
+		p.Content = container.NewStack()
+		p.Refresh()
+	case *container.ThemeOverride:
+		p.Content = container.NewStack()
+		p.Refresh()
+	case *widget.Card:
+		p.SetContent(container.NewStack())
+	}
+
+	return parent
+}
+
+// replace puts o in the place of old within the design, keeping its position, any Border slot and its bounds
+// in a "Free" layout. It returns false if old is not in the design.
+func (b *Builder) replace(old, o fyne.CanvasObject) bool {
+	if old == b.root {
+		b.moveDesignProperties(old, o)
+		b.root = o
+		if b.design != nil {
+			b.design.Objects[0] = o
+			b.design.Refresh()
+		}
+		return true
+	}
+
+	parent := parentOf(b.root, old)
+	if parent != nil {
+		b.moveProperties(old, o, guidefs.IsFreeProperty)
+	}
+	switch p := parent.(type) {
+	case *fyne.Container:
+		p.Objects[indexIn(p, old)] = o
+		b.relayout(p)
+	case *container.AppTabs:
+		for _, item := range p.Items {
+			if item.Content == old {
+				item.Content = o
+			}
+		}
+		p.Refresh()
+	case *container.Split:
+		if p.Leading == old {
+			p.Leading = o
+		} else {
+			p.Trailing = o
+		}
+		p.Refresh()
+	case *container.Scroll:
+		p.Content = o
+		p.Refresh()
+	case *container.ThemeOverride:
+		p.Content = o
+		p.Refresh()
+	case *widget.Card:
+		p.SetContent(o)
+	default:
+		return false
+	}
+	return true
+}
+
+// insert adds o to the parent container, at index if it is a *fyne.Container and index is valid.
+// If slot is set the object is also assigned to that slot of a Border layout, or replaces that side of a Split.
+func (b *Builder) insert(parent fyne.CanvasObject, index int, slot string, o fyne.CanvasObject) {
+	if s, ok := parent.(*container.Split); ok && slot != "" {
+		if slot == "leading" {
+			s.Leading = o
+		} else {
+			s.Trailing = o
+		}
+		s.Refresh()
+		return
+	}
+
+	c, ok := parent.(*fyne.Container)
+	if !ok {
+		info := guidefs.Lookup(reflect.TypeOf(parent).String())
+		if info != nil && info.AddChild != nil {
+			info.AddChild(parent, o)
+		}
+		return
+	}
+
+	if index < 0 || index >= len(c.Objects) {
+		index = len(c.Objects)
+		c.Objects = append(c.Objects, o)
+	} else {
+		c.Objects = append(c.Objects[:index], append([]fyne.CanvasObject{o}, c.Objects[index:]...)...)
+		shiftBorderSlots(b.meta[c], index, 1)
+	}
+	if slot != "" {
+		b.meta[c][slot] = strconv.Itoa(index)
+	}
+	b.relayout(c)
+}
+
+// relayout re-creates the layout of a container so that layouts referencing children are correct.
+func (b *Builder) relayout(c *fyne.Container) {
+	if lay, ok := guidefs.LookupLayout(b, b.meta[c]["layout"]); ok {
+		c.Layout = lay.Create(c, b)
+	}
+	c.Refresh()
+	if b.overlay != nil {
+		b.overlay.highlight(b.current)
+	}
+}
+
+// shiftBorderSlots updates the Border slot indexes stored in props after children are inserted or removed at index.
+// A slot that referenced a removed child is cleared.
+func shiftBorderSlots(props map[string]string, index, delta int) {
+	if props == nil || props["layout"] != "Border" {
+		return
+	}
+
+	for _, slot := range borderSlots {
+		if props[slot] == "" {
+			continue
+		}
+		id, err := strconv.Atoi(props[slot])
+		if err != nil {
+			continue
+		}
+
+		if delta < 0 && id == index {
+			props[slot] = ""
+		} else if id >= index {
+			props[slot] = strconv.Itoa(id + delta)
+		}
+	}
+}
+
+// reorder moves the child of a container at index from to index to, keeping Border slots with their objects.
+func (b *Builder) reorder(c *fyne.Container, from, to int) {
+	if from == to || from < 0 || to < 0 || from >= len(c.Objects) || to >= len(c.Objects) {
+		return
+	}
+
+	o := c.Objects[from]
+	if from < to {
+		copy(c.Objects[from:to], c.Objects[from+1:to+1])
+	} else {
+		copy(c.Objects[to+1:from+1], c.Objects[to:from])
+	}
+	c.Objects[to] = o
+
+	props := b.meta[c]
+	if props != nil && props["layout"] == "Border" {
+		for _, slot := range borderSlots {
+			id, err := strconv.Atoi(props[slot])
+			if props[slot] == "" || err != nil {
+				continue
+			}
+
+			switch {
+			case id == from:
+				id = to
+			case from < to && id > from && id <= to:
+				id--
+			case from > to && id >= to && id < from:
+				id++
+			}
+			props[slot] = strconv.Itoa(id)
+		}
+	}
+	b.relayout(c)
+}
+
+// indexIn returns the position of o in the children of parent, or -1 if it is not a direct child.
+func indexIn(parent, o fyne.CanvasObject) int {
+	for i, child := range children(parent) {
+		if child == o {
+			return i
+		}
+	}
+	return -1
+}
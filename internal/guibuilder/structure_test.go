@@ -0,0 +1,146 @@
+package guibuilder
+
+import (
+	"strconv"
+	"testing"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const borderDesign = `{"Type": "*fyne.Container", "Layout": "Border", "Properties": {"top": "0", "bottom": "1", "left": "3"},
+	"Objects": [
+	{"Type": "*widget.Label", "Name": "top", "Struct": {"Text": "Top"}},
+	{"Type": "*widget.Label", "Name": "bottom", "Struct": {"Text": "Bottom"}},
+	{"Type": "*widget.Label", "Name": "middle", "Struct": {"Text": "Middle"}},
+	{"Type": "*widget.Label", "Name": "left", "Struct": {"Text": "Left"}}
+]}`
+
+func TestShiftBorderSlots(t *testing.T) {
+	for name, tt := range map[string]struct {
+		index, delta int
+		expected     map[string]string
+	}{
+		"insert first":  {0, 1, map[string]string{"top": "1", "bottom": "3", "left": "", "right": "4"}},
+		"insert middle": {1, 1, map[string]string{"top": "0", "bottom": "3", "left": "", "right": "4"}},
+		"append":        {4, 1, map[string]string{"top": "0", "bottom": "2", "left": "", "right": "3"}},
+		"remove first":  {0, -1, map[string]string{"top": "", "bottom": "1", "left": "", "right": "2"}},
+		"remove slot":   {2, -1, map[string]string{"top": "0", "bottom": "", "left": "", "right": "2"}},
+		"remove last":   {3, -1, map[string]string{"top": "0", "bottom": "2", "left": "", "right": ""}},
+		"remove middle": {1, -1, map[string]string{"top": "0", "bottom": "1", "left": "", "right": "2"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			props := map[string]string{"layout": "Border", "top": "0", "bottom": "2", "left": "", "right": "3"}
+			shiftBorderSlots(props, tt.index, tt.delta)
+			delete(props, "layout")
+			assert.Equal(t, tt.expected, props)
+		})
+	}
+
+	props := map[string]string{"layout": "VBox", "top": "0"}
+	shiftBorderSlots(props, 0, 1)
+	assert.Equal(t, "0", props["top"])
+}
+
+func TestReorderBorderSlots(t *testing.T) {
+	for name, tt := range map[string]struct {
+		from int
+		last bool
+	}{
+		"top to first":    {0, false},
+		"top to last":     {0, true},
+		"bottom to first": {1, false},
+		"bottom to last":  {1, true},
+		"middle to first": {2, false},
+		"middle to last":  {2, true},
+		"left to first":   {3, false},
+		"left to last":    {3, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			b := newTestBuilder(t, borderDesign)
+			root := b.root.(*fyne.Container)
+			slots := borderObjects(b, root)
+			moving := root.Objects[tt.from]
+
+			b.moveTo(moving, tt.last)
+			if tt.last {
+				assert.Same(t, moving, root.Objects[len(root.Objects)-1])
+			} else {
+				assert.Same(t, moving, root.Objects[0])
+			}
+			assert.Equal(t, slots, borderObjects(b, root))
+
+			b.Undo()
+			assert.Same(t, moving, root.Objects[tt.from])
+			assert.Equal(t, slots, borderObjects(b, root))
+		})
+	}
+}
+
+func TestWrapUnwrap(t *testing.T) {
+	for name, tt := range map[string]struct {
+		design string
+		kind   string
+		child  func(root *fyne.Container) fyne.CanvasObject
+	}{
+		"border": {borderDesign, wrapLayoutPrefix + "VBox", func(root *fyne.Container) fyne.CanvasObject {
+			return root.Objects[0]
+		}},
+		"split": {`{"Type": "*fyne.Container", "Layout": "VBox", "Objects": [{"Type": "*container.Split",
+			"Struct": {"Horizontal": true, "Offset": 0.5,
+				"Leading": {"Type": "*widget.Label", "Name": "lead", "Struct": {"Text": "Leading"}},
+				"Trailing": {"Type": "*widget.Label", "Name": "trail", "Struct": {"Text": "Trailing"}}}}]}`,
+			"Card", func(root *fyne.Container) fyne.CanvasObject {
+				return children(root.Objects[0])[1]
+			}},
+		"card": {`{"Type": "*fyne.Container", "Layout": "VBox", "Objects": [{"Type": "*widget.Card",
+			"Struct": {"Title": "Card", "Subtitle": "",
+				"Content": {"Type": "*widget.Label", "Name": "content", "Struct": {"Text": "Content"}}}}]}`,
+			"Scroll", func(root *fyne.Container) fyne.CanvasObject {
+				return children(root.Objects[0])[0]
+			}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			b := newTestBuilder(t, tt.design)
+			root := b.root.(*fyne.Container)
+			child := tt.child(root)
+			parent := parentOf(b.root, child)
+			index := indexIn(parent, child)
+			slots := borderObjects(b, root)
+
+			b.wrap(child, tt.kind)
+			wrapper := children(parent)[index]
+			assert.NotSame(t, child, wrapper)
+			assert.Same(t, wrapper, parentOf(b.root, child))
+			if slots != nil {
+				slots["top"] = wrapper
+				assert.Equal(t, slots, borderObjects(b, root))
+			}
+
+			b.unwrap(wrapper)
+			assert.Same(t, child, children(parent)[index])
+			assert.Same(t, parent, parentOf(b.root, child))
+			if slots != nil {
+				slots["top"] = child
+				assert.Equal(t, slots, borderObjects(b, root))
+			}
+		})
+	}
+}
+
+// borderObjects returns the object in each slot of a Border container, or nil if it uses another layout.
+func borderObjects(b *Builder, c *fyne.Container) map[string]fyne.CanvasObject {
+	props := b.meta[c]
+	if props["layout"] != "Border" {
+		return nil
+	}
+
+	slots := make(map[string]fyne.CanvasObject)
+	for _, slot := range borderSlots {
+		if id, err := strconv.Atoi(props[slot]); err == nil {
+			slots[slot] = c.Objects[id]
+		}
+	}
+	return slots
+}
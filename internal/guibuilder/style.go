@@ -0,0 +1,260 @@
+package guibuilder
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/fyne-io/defyne/pkg/gui"
+)
+
+const unsetStyleLabel = "(Unset)"
+
+// showStyles lets the user edit the named styles of the project.
+// Applying the changes saves the style sheet, updates this design and regenerates the code of every other design.
+func (b *Builder) showStyles() {
+	list := guidefs.Styles(b)
+	current := -1
+
+	name := widget.NewEntry()
+	importance := widget.NewSelect(append([]string{unsetStyleLabel}, guidefs.StyleImportances()...), nil)
+	align := widget.NewSelect(append([]string{unsetStyleLabel}, guidefs.StyleAlignments...), nil)
+	setText := widget.NewCheck("", nil)
+	bold, italic, mono := widget.NewCheck("Bold", nil), widget.NewCheck("Italic", nil), widget.NewCheck("Monospace", nil)
+	col := widget.NewSelectEntry(guidefs.StyleColors())
+	col.SetPlaceHolder("Theme colour or #rrggbb")
+	size := widget.NewEntry()
+	size.SetPlaceHolder("Unset")
+	size.Validator = func(s string) error {
+		if s == "" {
+			return nil
+		}
+		if f, err := strconv.ParseFloat(s, 32); err != nil || f <= 0 {
+			return errors.New("text size must be a positive number")
+		}
+		return nil
+	}
+	setText.OnChanged = func(on bool) {
+		for _, c := range []*widget.Check{bold, italic, mono} {
+			if on {
+				c.Enable()
+			} else {
+				c.Disable()
+			}
+		}
+	}
+	form := widget.NewForm(
+		widget.NewFormItem("Name", name),
+		widget.NewFormItem("Importance", importance),
+		widget.NewFormItem("Alignment", align),
+		widget.NewFormItem("Text Style", container.NewHBox(setText, bold, italic, mono)),
+		widget.NewFormItem("Color", col),
+		widget.NewFormItem("Text Size", size))
+	form.Hide()
+
+	var styles *widget.List
+	// store copies the form into the style being edited, so that it is kept when another is selected
+	store := func() {
+		if current < 0 || current >= len(list) {
+			return
+		}
+
+		s := guidefs.Style{Name: strings.TrimSpace(name.Text), Color: strings.TrimSpace(col.Text)}
+		if importance.Selected != unsetStyleLabel {
+			s.Importance = importance.Selected
+		}
+		if align.Selected != unsetStyleLabel {
+			s.Alignment = align.Selected
+		}
+		if setText.Checked {
+			s.TextStyle = &fyne.TextStyle{Bold: bold.Checked, Italic: italic.Checked, Monospace: mono.Checked}
+		}
+		if f, err := strconv.ParseFloat(size.Text, 32); err == nil && f > 0 {
+			s.TextSize = float32(f)
+		}
+		list[current] = s
+		styles.RefreshItem(current)
+	}
+	show := func(s guidefs.Style) {
+		name.SetText(s.Name)
+		importance.SetSelected(unsetStyleLabel)
+		if s.Importance != "" {
+			importance.SetSelected(s.Importance)
+		}
+		align.SetSelected(unsetStyleLabel)
+		if s.Alignment != "" {
+			align.SetSelected(s.Alignment)
+		}
+		setText.SetChecked(s.TextStyle != nil)
+		text := fyne.TextStyle{}
+		if s.TextStyle != nil {
+			text = *s.TextStyle
+		}
+		bold.SetChecked(text.Bold)
+		italic.SetChecked(text.Italic)
+		mono.SetChecked(text.Monospace)
+		setText.OnChanged(setText.Checked)
+		col.SetText(s.Color)
+		size.SetText("")
+		if s.TextSize > 0 {
+			size.SetText(strconv.FormatFloat(float64(s.TextSize), 'f', -1, 32))
+		}
+		form.Show()
+	}
+
+	styles = widget.NewList(
+		func() int {
+			return len(list)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("style name")
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(list[id].Name)
+		})
+	styles.OnSelected = func(id widget.ListItemID) {
+		store()
+		current = id
+		show(list[id])
+	}
+	add := widget.NewButtonWithIcon("", theme.ContentAddIcon(), func() {
+		store()
+		list = append(list, guidefs.Style{Name: "style" + strconv.Itoa(len(list)+1)})
+		styles.Refresh()
+		styles.Select(len(list) - 1)
+	})
+	remove := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+		if current < 0 || current >= len(list) {
+			return
+		}
+
+		list = append(list[:current], list[current+1:]...)
+		current = -1
+		styles.UnselectAll()
+		styles.Refresh()
+		form.Hide()
+	})
+
+	left := container.NewBorder(nil, container.NewHBox(add, remove), nil, nil, styles)
+	split := container.NewHSplit(left, container.NewVScroll(form))
+	split.Offset = 0.3
+	d := dialog.NewCustomConfirm("Styles", "Apply", "Cancel", split, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		store()
+		prev := guidefs.Styles(b)
+		if err := guidefs.SaveProjectStyles(b, list); err != nil {
+			dialog.ShowError(err, b.win)
+			return
+		}
+		b.UpdateStyles()
+		if err := b.regenerateProjectDesigns(prev); err != nil {
+			dialog.ShowError(err, b.win)
+		}
+		if b.OnStylesChanged != nil {
+			b.OnStylesChanged()
+		}
+	}, b.win)
+	d.Resize(fyne.NewSize(560, 420))
+	d.Show()
+}
+
+// UpdateStyles updates the objects in this design to use the current style properties of the project.
+// Properties that were set by the previous version of a style, but are no longer, are reset.
+func (b *Builder) UpdateStyles() {
+	b.restyle()
+	b.recordChange(editChange, b.allObjects()...)
+	if b.current != nil {
+		b.choose(b.current)
+	}
+}
+
+// restyle resets the objects from the styles last applied to this design and then applies the current styles.
+func (b *Builder) restyle() {
+	prev := b.styles
+	b.styles = guidefs.Styles(b)
+	walk(b.root, func(o fyne.CanvasObject) {
+		reset := guidefs.ResetStyle(o, prev, b)
+		if guidefs.ApplyStyle(o, b) || reset {
+			o.Refresh()
+		}
+	})
+}
+
+// regenerateProjectDesigns writes the Go code for all other designs in the project, so that style changes
+// are resolved in their generated code without opening each of them.
+// The prev styles are those that the designs were saved with.
+func (b *Builder) regenerateProjectDesigns(prev []guidefs.Style) error {
+	for _, u := range b.projectDesigns() {
+		if u.String() == b.uri.String() {
+			continue
+		}
+
+		if err := regenerateDesign(u, b.win, prev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectDesigns returns the GUI design files found in the project directory.
+func (b *Builder) projectDesigns() []fyne.URI {
+	root := b.ProjectRoot()
+	if root == nil {
+		return nil
+	}
+
+	var designs []fyne.URI
+	var search func(dir fyne.URI)
+	search = func(dir fyne.URI) {
+		items, err := storage.List(dir)
+		if err != nil {
+			return
+		}
+		for _, u := range items {
+			if strings.HasPrefix(u.Name(), ".") {
+				continue
+			}
+			if ok, _ := storage.CanList(u); ok {
+				search(u)
+				continue
+			}
+
+			if strings.HasSuffix(u.Name(), ".gui.json") {
+				designs = append(designs, u)
+			}
+		}
+	}
+	search(root)
+	return designs
+}
+
+func regenerateDesign(u fyne.URI, win fyne.Window, prev []guidefs.Style) error {
+	r, err := storage.Reader(u)
+	if err != nil {
+		return err
+	}
+	other := &Builder{uri: u, win: win, meta: make(map[fyne.CanvasObject]map[string]string)}
+	obj, _, err := gui.DecodeObject(r, other)
+	_ = r.Close()
+	if err != nil || obj == nil {
+		fyne.LogError("Skipping design "+u.Name(), err)
+		return nil
+	}
+
+	other.root = obj
+	other.styles = prev
+	other.restyle()
+	dir, _ := storage.Parent(u)
+	return other.exportGo(dir, strings.TrimSuffix(u.Name(), ".gui.json"))
+}
@@ -0,0 +1,153 @@
+package guibuilder
+
+import (
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+const wrapLayoutPrefix = "Container: "
+
+// wrappers lists the containers, other than *fyne.Container, that an object can be wrapped in.
+var wrappers = map[string]string{
+	"Card":          "*widget.Card",
+	"Scroll":        "*container.Scroll",
+	"Split":         "*container.Split",
+	"ThemeOverride": "*container.ThemeOverride",
+}
+
+func wrapOptions(c guidefs.DefyneContext) []string {
+	var layouts, others []string
+	for _, name := range guidefs.LayoutNames(c) {
+		layouts = append(layouts, wrapLayoutPrefix+name)
+	}
+	for name := range wrappers {
+		others = append(others, name)
+	}
+	sort.Strings(layouts)
+	sort.Strings(others)
+
+	return append(layouts, others...)
+}
+
+// moveTo moves an object to the first or last position amongst its siblings.
+func (b *Builder) moveTo(o fyne.CanvasObject, last bool) {
+	parent, ok := parentOf(b.root, o).(*fyne.Container)
+	if !ok {
+		return
+	}
+
+	to := 0
+	if last {
+		to = len(parent.Objects) - 1
+	}
+	b.reorder(parent, indexIn(parent, o), to)
+	b.choose(o)
+	b.recordChange(structureChange, parent)
+}
+
+// wrap places a new container in the position of o, with o as its content.
+// The kind is either one of the wrappers or a layout name with the wrapLayoutPrefix.
+func (b *Builder) wrap(o fyne.CanvasObject, kind string) {
+	if o == nil {
+		return
+	}
+
+	parent := parentOf(b.root, o)
+	var wrapper fyne.CanvasObject
+	if class, ok := wrappers[kind]; ok {
+		wrapper = guidefs.Lookup(class).Create(b)
+		b.meta[wrapper] = map[string]string{}
+		if !b.replace(o, wrapper) {
+			return
+		}
+
+		if split, ok := wrapper.(*container.Split); ok {
+			split.Leading = o
+			split.Refresh()
+		} else {
+			b.insert(wrapper, -1, "", o)
+		}
+	} else {
+		name := kind[len(wrapLayoutPrefix):]
+		if _, ok := guidefs.LookupLayout(b, name); !ok {
+			return
+		}
+
+		c := container.NewStack()
+		b.meta[c] = map[string]string{"layout": name}
+		if !b.replace(o, c) {
+			return
+		}
+		c.Objects = []fyne.CanvasObject{o}
+		b.relayout(c)
+		wrapper = c
+	}
+
+	b.choose(wrapper)
+	b.recordChange(structureChange, parent, o, wrapper)
+}
+
+// unwrap replaces a container with the single object it holds, discarding the container.
+func (b *Builder) unwrap(o fyne.CanvasObject) {
+	child := onlyChild(o)
+	if child == nil {
+		return
+	}
+
+	parent := parentOf(b.root, o)
+	if !b.replace(o, child) {
+		return
+	}
+
+	b.choose(child)
+	b.recordChange(structureChange, parent, o, child)
+}
+
+// onlyChild returns the one object inside a container, ignoring empty placeholders,
+// or nil if it is not a container or holds more or less than one object.
+func onlyChild(o fyne.CanvasObject) fyne.CanvasObject {
+	if o == nil || !isContainer(o) {
+		return nil
+	}
+
+	var found fyne.CanvasObject
+	for _, child := range children(o) {
+		if child == nil || isPlaceholder(child) {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = child
+	}
+	return found
+}
+
+func isPlaceholder(o fyne.CanvasObject) bool {
+	c, ok := o.(*fyne.Container)
+	return ok && len(c.Objects) == 0
+}
+
+func (b *Builder) showWrap(o fyne.CanvasObject) {
+	if o == nil {
+		return
+	}
+
+	kind := widget.NewSelect(wrapOptions(b), nil)
+	kind.SetSelected(wrapLayoutPrefix + "VBox")
+	dialog.ShowForm("Wrap in container", "Wrap", "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("Container", kind)},
+		func(ok bool) {
+			if !ok || kind.Selected == "" {
+				return
+			}
+
+			b.wrap(o, kind.Selected)
+		}, b.win)
+}
@@ -0,0 +1,37 @@
+package guibuilder
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapFreeBounds(t *testing.T) {
+	b := newTestBuilder(t, `{"Type": "*fyne.Container", "Layout": "Free", "Objects": [
+	{"Type": "*widget.Label", "Name": "title", "Struct": {"Text": "Hello"},
+		"Properties": {"free.x": "10", "free.y": "20", "free.width": "80", "free.height": "30"}}
+]}`)
+	root := b.root.(*fyne.Container)
+	label := root.Objects[0].(*widget.Label)
+
+	b.wrap(label, "Card")
+	card := root.Objects[0]
+	require.IsType(t, &widget.Card{}, card)
+	pos, size := guidefs.FreeBounds(card, b)
+	assert.Equal(t, fyne.NewPos(10, 20), pos)
+	assert.Equal(t, fyne.NewSize(80, 30), size)
+	for k := range b.meta[label] {
+		assert.False(t, guidefs.IsFreeProperty(k), "label kept %s inside the card", k)
+	}
+
+	b.unwrap(card)
+	assert.Same(t, label, root.Objects[0])
+	pos, size = guidefs.FreeBounds(label, b)
+	assert.Equal(t, fyne.NewPos(10, 20), pos)
+	assert.Equal(t, fyne.NewSize(80, 30), size)
+}
@@ -0,0 +1,187 @@
+package guidefs
+
+import (
+	"encoding/json"
+	"image/color"
+	"reflect"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+const (
+	customColorLabel = "(Custom)"
+	themeColorPrefix = "color."
+)
+
+var (
+	colorType = reflect.TypeOf((*color.Color)(nil)).Elem()
+
+	// themeColorLabels lists the theme colours that can be used, by the suffix of their `theme.ColorName` constant
+	themeColorLabels = []string{"Background", "Button", "Disabled", "DisabledButton", "Error", "Focus", "Foreground",
+		"ForegroundOnError", "ForegroundOnPrimary", "ForegroundOnSuccess", "ForegroundOnWarning", "HeaderBackground",
+		"Hover", "Hyperlink", "InputBackground", "InputBorder", "MenuBackground", "OverlayBackground", "PlaceHolder",
+		"Pressed", "Primary", "ScrollBar", "Selection", "Separator", "Shadow", "Success", "Warning"}
+
+	themeColorNames = map[string]fyne.ThemeColorName{
+		"Background":          theme.ColorNameBackground,
+		"Button":              theme.ColorNameButton,
+		"Disabled":            theme.ColorNameDisabled,
+		"DisabledButton":      theme.ColorNameDisabledButton,
+		"Error":               theme.ColorNameError,
+		"Focus":               theme.ColorNameFocus,
+		"Foreground":          theme.ColorNameForeground,
+		"ForegroundOnError":   theme.ColorNameForegroundOnError,
+		"ForegroundOnPrimary": theme.ColorNameForegroundOnPrimary,
+		"ForegroundOnSuccess": theme.ColorNameForegroundOnSuccess,
+		"ForegroundOnWarning": theme.ColorNameForegroundOnWarning,
+		"HeaderBackground":    theme.ColorNameHeaderBackground,
+		"Hover":               theme.ColorNameHover,
+		"Hyperlink":           theme.ColorNameHyperlink,
+		"InputBackground":     theme.ColorNameInputBackground,
+		"InputBorder":         theme.ColorNameInputBorder,
+		"MenuBackground":      theme.ColorNameMenuBackground,
+		"OverlayBackground":   theme.ColorNameOverlayBackground,
+		"PlaceHolder":         theme.ColorNamePlaceHolder,
+		"Pressed":             theme.ColorNamePressed,
+		"Primary":             theme.ColorNamePrimary,
+		"ScrollBar":           theme.ColorNameScrollBar,
+		"Selection":           theme.ColorNameSelection,
+		"Separator":           theme.ColorNameSeparator,
+		"Shadow":              theme.ColorNameShadow,
+		"Success":             theme.ColorNameSuccess,
+		"Warning":             theme.ColorNameWarning,
+	}
+)
+
+// VariantTheme draws a theme in one variant, whatever the variant of the app.
+// Theme colours in a design that is drawn with it use the same variant.
+type VariantTheme struct {
+	fyne.Theme
+
+	variant fyne.ThemeVariant
+}
+
+// NewVariantTheme returns a theme that always shows the light or dark variant of th.
+func NewVariantTheme(th fyne.Theme, v fyne.ThemeVariant) *VariantTheme {
+	return &VariantTheme{Theme: th, variant: v}
+}
+
+func (t *VariantTheme) Color(n fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	return t.Theme.Color(n, t.variant)
+}
+
+// Variant returns the variant that this theme is shown in.
+func (t *VariantTheme) Variant() fyne.ThemeVariant {
+	return t.variant
+}
+
+// fixedVariant is implemented by themes, such as VariantTheme, that choose the variant they are shown in.
+type fixedVariant interface {
+	Variant() fyne.ThemeVariant
+}
+
+// themeColor is a color that looks up a named theme colour every time it is drawn.
+// The theme, and its variant, come from the context so that previews and exported images follow
+// the theme that they are drawn with.
+type themeColor struct {
+	name fyne.ThemeColorName
+	ctx  DefyneContext
+}
+
+func newThemeColor(name fyne.ThemeColorName, c DefyneContext) *themeColor {
+	return &themeColor{name: name, ctx: c}
+}
+
+// MarshalJSON writes the current value of this colour so that the object data remains readable.
+// The theme colour name is stored in the object properties.
+func (t *themeColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(color.NRGBAModel.Convert(t))
+}
+
+func (t *themeColor) RGBA() (r, g, b, a uint32) {
+	var th fyne.Theme
+	if t.ctx != nil {
+		th = t.ctx.Theme()
+	}
+
+	v := theme.VariantDark
+	if app := fyne.CurrentApp(); app != nil {
+		if th == nil {
+			th = app.Settings().Theme()
+		}
+		v = app.Settings().ThemeVariant()
+	}
+	if th == nil {
+		th = theme.DefaultTheme()
+	}
+	if fixed, ok := th.(fixedVariant); ok {
+		v = fixed.Variant()
+	}
+	return th.Color(t.name, v).RGBA()
+}
+
+func (t *themeColor) goString() string {
+	return "theme.Color(theme.ColorName" + themeColorLabel(t.name) + ")"
+}
+
+func themeColorLabel(name fyne.ThemeColorName) string {
+	for label, n := range themeColorNames {
+		if n == name {
+			return label
+		}
+	}
+
+	return ""
+}
+
+// colorPackages returns the packages needed to create an object that has color fields.
+func colorPackages(obj fyne.CanvasObject) []string {
+	pkgs := []string{"canvas"}
+	v := reflect.ValueOf(obj).Elem()
+	hasColor, hasTheme := false, false
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if !v.Type().Field(i).IsExported() || f.Type() != colorType {
+			continue
+		}
+
+		switch f.Interface().(type) {
+		case nil:
+		case *themeColor:
+			hasTheme = true
+		default:
+			hasColor = true
+		}
+	}
+
+	if hasColor {
+		pkgs = append(pkgs, "image/color")
+	}
+	if hasTheme {
+		pkgs = append(pkgs, "theme")
+	}
+	return pkgs
+}
+
+// restoreThemeColors sets any color fields that are named as theme colours in the object's properties.
+func restoreThemeColors(obj fyne.CanvasObject, c DefyneContext) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	v = v.Elem()
+	for k, name := range c.Metadata()[obj] {
+		if !strings.HasPrefix(k, themeColorPrefix) || name == "" {
+			continue
+		}
+
+		f := v.FieldByName(k[len(themeColorPrefix):])
+		if !f.IsValid() || !f.CanSet() || f.Type() != colorType {
+			continue
+		}
+		f.Set(reflect.ValueOf(newThemeColor(fyne.ThemeColorName(name), c)))
+	}
+}
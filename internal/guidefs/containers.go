@@ -23,11 +23,16 @@ func initContainers() {
 				props := ctx.Metadata()[obj]
 				c := obj.(*fyne.Container)
 
-				choose := widget.NewFormItem("Layout", widget.NewSelect(layoutNames, nil))
+				choose := widget.NewFormItem("Layout", widget.NewSelect(LayoutNames(ctx), nil))
 				items := []*widget.FormItem{choose}
 				ready := false
 				choose.Widget.(*widget.Select).OnChanged = func(l string) {
-					lay := Layouts[l]
+					lay, _ := LookupLayout(ctx, l)
+					if props["layout"] == "Free" && l != "Free" {
+						for _, o := range c.Objects {
+							ClearFreeBounds(o, ctx)
+						}
+					}
 					props["layout"] = l
 					c.Layout = lay.Create(c, ctx)
 					c.Refresh()
@@ -35,7 +40,7 @@ func initContainers() {
 					edit := lay.Edit
 					items = []*widget.FormItem{choose}
 					if edit != nil {
-						items = append(items, edit(c, ctx)...)
+						items = append(items, edit(c, ctx, onchanged)...)
 					}
 
 					if ready {
@@ -54,7 +59,7 @@ func initContainers() {
 				if l == "" {
 					l = "VBox"
 				}
-				lay := Layouts[l]
+				lay, _ := LookupLayout(ctx, l)
 				if lay.goText != nil {
 					code := lay.goText(c, ctx, defs)
 					return widgetRef(props[obj], defs, code)
@@ -83,7 +88,7 @@ func initContainers() {
 				return children
 			},
 			AddChild: func(parent, o fyne.CanvasObject) {
-				tabs := o.(*container.AppTabs)
+				tabs := parent.(*container.AppTabs)
 
 				item := container.NewTabItem("Untitled", o)
 				tabs.Append(item)
@@ -289,7 +294,7 @@ func initContainers() {
 				return []fyne.CanvasObject{over.Content}
 			},
 			AddChild: func(parent, o fyne.CanvasObject) {
-				over := o.(*container.ThemeOverride)
+				over := parent.(*container.ThemeOverride)
 				over.Content = o
 				over.Refresh()
 			},
@@ -300,9 +305,11 @@ func initContainers() {
 				props := c.Metadata()[obj]
 				over := obj.(*container.ThemeOverride)
 				custom := widget.NewMultiLineEntry()
+				custom.TextStyle = fyne.TextStyle{Monospace: true}
 				custom.SetText(props["data"])
+				custom.Validator = validateThemeJSON
 				custom.OnChanged = func(s string) {
-					th, err := theme.FromJSONWithFallback(s, c.Theme())
+					th, err := ThemeFromJSON(s, c)
 					if err != nil {
 						return
 					}
@@ -312,8 +319,12 @@ func initContainers() {
 					over.Refresh()
 					onchanged()
 				}
+				edit := widget.NewButtonWithIcon("Edit Theme...", theme.ColorPaletteIcon(), func() {
+					showThemeEditor(custom.Text, c, custom.SetText)
+				})
 
 				return []*widget.FormItem{
+					widget.NewFormItem("Theme", edit),
 					widget.NewFormItem("Theme Data", custom),
 				}
 			},
@@ -324,9 +335,12 @@ func initContainers() {
 				str.WriteString("container.NewThemeOverride(")
 				writeGoStringExcluding(str, nil, c, defs, over.Content)
 				str.WriteString(", ")
+				data, fonts := themeGoJSON(props["data"])
 				str.WriteString("func() fyne.Theme { th, _ := theme.FromJSONWithFallback(`")
-				str.WriteString(props["data"])
-				str.WriteString("`, fyne.CurrentApp().Settings().Theme()); return th}())")
+				str.WriteString(data)
+				str.WriteString("`, fyne.CurrentApp().Settings().Theme()); return ")
+				str.WriteString(themeFontsGo(fonts))
+				str.WriteString("}())")
 				return widgetRef(props, defs, str.String())
 			},
 			Packages: func(_ fyne.CanvasObject, _ DefyneContext) []string {
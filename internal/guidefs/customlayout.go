@@ -0,0 +1,253 @@
+package guidefs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// CustomLayoutsFile is the name of the file, in the project root, that lists project layouts.
+const CustomLayoutsFile = "layouts.defyne.json"
+
+const (
+	customLayoutPrefix = "layout."
+	defaultPreview     = "VBox"
+)
+
+// CustomLayout describes a fyne.Layout that is implemented in the project being designed.
+// The layout code cannot run inside the builder, so a built-in layout is used to preview it.
+type CustomLayout struct {
+	// Name is shown in the layout selector and stored in the design.
+	Name string
+	// Type is the Go type, in the generated package, that implements fyne.Layout.
+	Type string
+	// Constructor is the Go expression that creates the layout, parameters are inserted using "{{name}}".
+	// If it is empty the expression "&Type{}" is used.
+	Constructor string `json:",omitempty"`
+	// Params are the values that can be edited for each container using this layout.
+	Params []LayoutParam `json:",omitempty"`
+	// Preview is the name of the built-in layout used to approximate this one, "VBox" if not set.
+	Preview string `json:",omitempty"`
+}
+
+// LayoutParam is an editable parameter of a custom layout.
+type LayoutParam struct {
+	Name string
+	// Type is one of "int", "float", "bool" or "string".
+	Type    string
+	Default string `json:",omitempty"`
+}
+
+// IsCustomLayout returns true if the named layout was registered from the project of the context.
+func IsCustomLayout(c DefyneContext, name string) bool {
+	_, ok := projectFor(c).layouts[name]
+	return ok
+}
+
+// LookupLayout returns the information about a built-in layout, or a custom layout of the project of the context.
+func LookupLayout(c DefyneContext, name string) (layoutInfo, bool) {
+	if lay, ok := Layouts[name]; ok {
+		return lay, true
+	}
+
+	l, ok := projectFor(c).layouts[name]
+	if !ok {
+		return layoutInfo{}, false
+	}
+	return l.info(), true
+}
+
+// LayoutNames returns the names of the built-in layouts and custom layouts of the project of the context, in order.
+func LayoutNames(c DefyneContext) []string {
+	custom := projectFor(c).layouts
+	if len(custom) == 0 {
+		return layoutNames
+	}
+
+	names := append([]string{}, layoutNames...)
+	for name := range custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterLayout adds a layout to the project of the context so that containers can be designed using it.
+// Registering a layout with the same name as an existing custom layout will replace it.
+func RegisterLayout(c DefyneContext, l CustomLayout) error {
+	if l.Name == "" {
+		return errors.New("custom layout requires a name")
+	}
+	if l.Type == "" && l.Constructor == "" {
+		return fmt.Errorf("custom layout %s requires a type or constructor", l.Name)
+	}
+	if _, ok := Layouts[l.Name]; ok {
+		return fmt.Errorf("custom layout %s clashes with a built-in layout", l.Name)
+	}
+	for _, p := range l.Params {
+		switch p.Type {
+		case "int", "float", "bool", "string":
+		default:
+			return fmt.Errorf("custom layout %s parameter %s has unsupported type %q", l.Name, p.Name, p.Type)
+		}
+	}
+	if prev, ok := Layouts[l.Preview]; !ok || prev.Create == nil {
+		l.Preview = defaultPreview
+	}
+
+	projectFor(c).layouts[l.Name] = l
+	return nil
+}
+
+// LoadLayouts reads a list of custom layouts in JSON format and registers them for the project of the context.
+func LoadLayouts(c DefyneContext, r io.Reader) error {
+	var list []CustomLayout
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return err
+	}
+
+	for _, l := range list {
+		if err := RegisterLayout(c, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadProjectLayouts replaces the custom layouts of the project of the context with those listed in its
+// CustomLayoutsFile, if any.
+func LoadProjectLayouts(c DefyneContext) {
+	projectFor(c).layouts = map[string]CustomLayout{}
+
+	p, ok := c.(ProjectContext)
+	if !ok || p.ProjectRoot() == nil {
+		return
+	}
+	u, err := storage.Child(p.ProjectRoot(), CustomLayoutsFile)
+	if err != nil {
+		return
+	}
+	if ok, _ := storage.Exists(u); !ok {
+		return
+	}
+
+	r, err := storage.Reader(u)
+	if err != nil {
+		fyne.LogError("Failed to open "+CustomLayoutsFile, err)
+		return
+	}
+	defer r.Close()
+	if err = LoadLayouts(c, r); err != nil {
+		fyne.LogError("Failed to load "+CustomLayoutsFile, err)
+	}
+}
+
+// info returns the layout information that previews this layout with a built-in one, and generates its code.
+func (l CustomLayout) info() layoutInfo {
+	return layoutInfo{
+		func(c *fyne.Container, d DefyneContext) fyne.Layout {
+			return Layouts[l.Preview].Create(c, d)
+		},
+		func(c *fyne.Container, d DefyneContext, onchanged func()) []*widget.FormItem {
+			return l.editItems(c, d, onchanged)
+		},
+		func(c *fyne.Container, d DefyneContext, defs map[string]string) string {
+			str := &strings.Builder{}
+			str.WriteString("container.New(")
+			str.WriteString(l.goExpression(d.Metadata()[c]))
+			str.WriteString(", ")
+			writeGoStringExcluding(str, nil, d, defs, c.Objects...)
+			str.WriteString(")")
+			return str.String()
+		},
+	}
+}
+
+func (l CustomLayout) editItems(c *fyne.Container, d DefyneContext, onchanged func()) []*widget.FormItem {
+	props := d.Metadata()[c]
+	items := []*widget.FormItem{
+		widget.NewFormItem("Type", widget.NewLabel(l.Type)),
+		widget.NewFormItem("Preview", widget.NewLabel(fmt.Sprintf("(approximated by %s)", l.Preview))),
+	}
+
+	for _, p := range l.Params {
+		key := customLayoutPrefix + p.Name
+		if p.Type == "bool" {
+			check := widget.NewCheck("", func(on bool) {
+				props[key] = strconv.FormatBool(on)
+				c.Refresh()
+				onchanged()
+			})
+			check.Checked = l.paramValue(props, p) == "true"
+			items = append(items, widget.NewFormItem(p.Name, check))
+			continue
+		}
+
+		param := p
+		value := widget.NewEntry()
+		value.SetText(l.paramValue(props, p))
+		value.Validator = func(s string) error {
+			switch param.Type {
+			case "int":
+				_, err := strconv.Atoi(s)
+				return err
+			case "float":
+				_, err := strconv.ParseFloat(s, 64)
+				return err
+			}
+			return nil
+		}
+		value.OnChanged = func(s string) {
+			if value.Validate() != nil {
+				return
+			}
+
+			props[key] = s
+			c.Refresh()
+			onchanged()
+		}
+		items = append(items, widget.NewFormItem(p.Name, value))
+	}
+	return items
+}
+
+func (l CustomLayout) goExpression(props map[string]string) string {
+	code := l.Constructor
+	if code == "" {
+		return "&" + l.Type + "{}"
+	}
+
+	for _, p := range l.Params {
+		value := l.paramValue(props, p)
+		if p.Type == "string" {
+			value = strconv.Quote(value)
+		}
+		code = strings.ReplaceAll(code, "{{"+p.Name+"}}", value)
+	}
+	return code
+}
+
+func (l CustomLayout) paramValue(props map[string]string, p LayoutParam) string {
+	if v, ok := props[customLayoutPrefix+p.Name]; ok {
+		return v
+	}
+	if p.Default != "" {
+		return p.Default
+	}
+
+	switch p.Type {
+	case "int", "float":
+		return "0"
+	case "bool":
+		return "false"
+	}
+	return ""
+}
@@ -0,0 +1,321 @@
+package guidefs
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"reflect"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	eventNoOp    = "No-op"
+	eventHandler = "Handler"
+	eventInline  = "Inline"
+)
+
+// constructorEvents lists the callbacks that a widget's Gostring already passes to its constructor.
+var constructorEvents = map[string]string{
+	"*widget.Button": "OnTapped",
+}
+
+// EventFields returns the names of the exported callback fields, such as OnTapped, of a widget.
+func EventFields(obj fyne.CanvasObject) []string {
+	t := reflect.TypeOf(obj)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for _, f := range reflect.VisibleFields(t.Elem()) {
+		if f.IsExported() && f.Type.Kind() == reflect.Func && strings.HasPrefix(f.Name, "On") {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// EventHandlers returns the handler methods that the actions of obj call, mapped to the parameters and results
+// of the method, such as "(v string)".
+func EventHandlers(obj fyne.CanvasObject, c DefyneContext) map[string]string {
+	props := c.Metadata()[obj]
+	handlers := make(map[string]string)
+	if props == nil {
+		return handlers
+	}
+
+	for _, field := range EventFields(obj) {
+		if name, ok := handlerName(props[field]); ok {
+			handlers[name] = strings.TrimPrefix(eventSignature(obj, field), "func")
+		}
+	}
+	if name := props[validationHandler]; name != "" {
+		handlers[name] = "(s string) error"
+	}
+	if name, ok := handlerName(props[rasterPixels]); ok {
+		handlers[name] = rasterSignature
+	}
+	if bar, ok := obj.(*widget.Toolbar); ok {
+		toolbarHandlers(bar, props, handlers)
+	}
+	return handlers
+}
+
+// EventImports returns the import paths of the types that the signature of each handler method of obj uses,
+// by method name. Handlers that only use builtin types are not included.
+func EventImports(obj fyne.CanvasObject, c DefyneContext) map[string][]string {
+	props := c.Metadata()[obj]
+	imports := make(map[string][]string)
+	for _, field := range EventFields(obj) {
+		if name, ok := handlerName(props[field]); ok {
+			if paths := eventImports(obj, field); len(paths) > 0 {
+				imports[name] = paths
+			}
+		}
+	}
+	if name, ok := handlerName(props[rasterPixels]); ok {
+		imports[name] = []string{"image/color"}
+	}
+	return imports
+}
+
+// EventPackages returns the import paths of the types that the signatures of the actions set for obj use.
+// The "fyne.io/fyne/v2" package is not included, as generated designs always import it.
+func EventPackages(obj fyne.CanvasObject, c DefyneContext) []string {
+	props := c.Metadata()[obj]
+	var pkgs []string
+	for _, field := range EventFields(obj) {
+		if props[field] == "" {
+			continue
+		}
+
+		for _, p := range eventImports(obj, field) {
+			if p != "fyne.io/fyne/v2" {
+				pkgs = append(pkgs, p)
+			}
+		}
+	}
+	return pkgs
+}
+
+// eventImports returns the import paths of the named types in the signature of a callback field.
+func eventImports(obj fyne.CanvasObject, field string) []string {
+	f, ok := reflect.TypeOf(obj).Elem().FieldByName(field)
+	if !ok {
+		return nil
+	}
+
+	paths := make(map[string]bool)
+	for i := 0; i < f.Type.NumIn(); i++ {
+		typeImports(f.Type.In(i), paths)
+	}
+	for i := 0; i < f.Type.NumOut(); i++ {
+		typeImports(f.Type.Out(i), paths)
+	}
+
+	list := make([]string, 0, len(paths))
+	for p := range paths {
+		list = append(list, p)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func typeImports(t reflect.Type, paths map[string]bool) {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+		typeImports(t.Elem(), paths)
+		return
+	case reflect.Map:
+		typeImports(t.Key(), paths)
+		typeImports(t.Elem(), paths)
+		return
+	}
+
+	if t.Name() != "" && t.PkgPath() != "" {
+		paths[t.PkgPath()] = true
+	}
+}
+
+// eventSignature returns the function type of a callback field, with parameter names, such as "func(v string)".
+func eventSignature(obj fyne.CanvasObject, field string) string {
+	f, ok := reflect.TypeOf(obj).Elem().FieldByName(field)
+	if !ok {
+		return "func()"
+	}
+
+	params := make([]string, f.Type.NumIn())
+	for i := range params {
+		name := "v"
+		if len(params) > 1 {
+			name = fmt.Sprintf("v%d", i+1)
+		}
+		params[i] = name + " " + f.Type.In(i).String()
+	}
+	sig := "func(" + strings.Join(params, ", ") + ")"
+
+	switch f.Type.NumOut() {
+	case 0:
+	case 1:
+		sig += " " + f.Type.Out(0).String()
+	default:
+		results := make([]string, f.Type.NumOut())
+		for i := range results {
+			results[i] = f.Type.Out(i).String()
+		}
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+func handlerName(code string) (string, bool) {
+	prefix := handlerRef("")
+	if !strings.HasPrefix(code, prefix) {
+		return "", false
+	}
+
+	name := code[len(prefix):]
+	if name == "" || ValidateIdentifier(name) != nil {
+		return "", false
+	}
+	return name, true
+}
+
+// inlineBody returns the statements of an inline action, removing the function signature that wraps them.
+func inlineBody(code, sig string) string {
+	if !strings.HasPrefix(code, sig+" {") || !strings.HasSuffix(code, "}") {
+		return code
+	}
+
+	return strings.TrimSpace(code[len(sig)+2 : len(code)-1])
+}
+
+// checkInline returns an error if the statements would not be valid Go code in a function with the signature.
+func checkInline(body, sig string) error {
+	_, err := parser.ParseExpr(sig + " {\n" + body + "\n}")
+	if err != nil {
+		return errors.New("syntax error: " + strings.TrimPrefix(err.Error(), "1:"))
+	}
+	return nil
+}
+
+// EventItems returns the form items to choose what happens for each callback of a widget.
+func EventItems(obj fyne.CanvasObject, c DefyneContext, onchanged func()) []*widget.FormItem {
+	fields := EventFields(obj)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	props := c.Metadata()[obj]
+	items := []*widget.FormItem{widget.NewFormItem("", widget.NewLabelWithStyle("Events", fyne.TextAlignLeading,
+		fyne.TextStyle{Bold: true}))}
+	for _, field := range fields {
+		items = append(items, widget.NewFormItem(field, eventEditor(props, field, eventSignature(obj, field), onchanged)))
+	}
+	return items
+}
+
+func eventEditor(props map[string]string, field, sig string, onchanged func()) fyne.CanvasObject {
+	handler := widget.NewEntry()
+	handler.SetPlaceHolder("method name")
+	handler.Validator = func(s string) error {
+		if s == "" {
+			return errors.New("a method name is required")
+		}
+		return ValidateIdentifier(s)
+	}
+	inline := widget.NewMultiLineEntry()
+	inline.TextStyle = fyne.TextStyle{Monospace: true}
+	inline.SetPlaceHolder("// Go statements, parameters are " + sig)
+	inline.Validator = func(s string) error {
+		return checkInline(s, sig)
+	}
+
+	mode := widget.NewSelect([]string{eventNoOp, eventHandler, eventInline}, nil)
+	code := props[field]
+	if name, ok := handlerName(code); ok {
+		handler.SetText(name)
+		mode.SetSelected(eventHandler)
+	} else if code != "" {
+		inline.SetText(inlineBody(code, sig))
+		mode.SetSelected(eventInline)
+	} else {
+		mode.SetSelected(eventNoOp)
+	}
+
+	ready := false
+	update := func() {
+		switch mode.Selected {
+		case eventHandler:
+			handler.Show()
+			inline.Hide()
+			if handler.Validate() != nil {
+				return
+			}
+			props[field] = handlerRef(handler.Text)
+		case eventInline:
+			handler.Hide()
+			inline.Show()
+			if inline.Validate() != nil {
+				return
+			}
+			props[field] = sig + " {\n" + inline.Text + "\n}"
+		default:
+			handler.Hide()
+			inline.Hide()
+			delete(props, field)
+		}
+		if ready {
+			onchanged()
+		}
+	}
+	update()
+	ready = true
+	mode.OnChanged = func(string) {
+		update()
+	}
+	handler.OnChanged = func(string) {
+		update()
+	}
+	inline.OnChanged = func(string) {
+		update()
+	}
+
+	return container.NewVBox(mode, handler, inline)
+}
+
+// eventsGoString adds the actions of a widget, that its Gostring did not already set, to the code creating it.
+func eventsGoString(clazz string, obj fyne.CanvasObject, c DefyneContext, defs map[string]string, code string) string {
+	props := c.Metadata()[obj]
+	var fields []string
+	for _, field := range EventFields(obj) {
+		if props[field] != "" && constructorEvents[clazz] != field {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return code
+	}
+	sort.Strings(fields)
+
+	wrap := func(create string) string {
+		str := &strings.Builder{}
+		str.WriteString("func() " + clazz + " {\n\tw := " + create + "\n")
+		for _, field := range fields {
+			str.WriteString("\tw." + field + " = " + props[field] + "\n")
+		}
+		str.WriteString("\treturn w\n}()")
+		return str.String()
+	}
+
+	if name := props["name"]; name != "" && code == fieldRef(name) {
+		defs[name] = wrap(defs[name])
+		return code
+	}
+	return wrap(code)
+}
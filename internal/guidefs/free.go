@@ -0,0 +1,134 @@
+package guidefs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// The metadata of each child in a container with the "Free" layout stores its position and size using these keys.
+const (
+	FreeX      = "free.x"
+	FreeY      = "free.y"
+	FreeWidth  = "free.width"
+	FreeHeight = "free.height"
+
+	freePropertyPrefix = "free."
+)
+
+// FreeSnapGrid is the metadata key of a "Free" container that sets the grid spacing that children snap to.
+const FreeSnapGrid = "snap_grid"
+
+const defaultSnapGrid = 8
+
+// IsFreeProperty returns true if the metadata key stores the position or size of an object in a "Free" layout.
+func IsFreeProperty(key string) bool {
+	return strings.HasPrefix(key, freePropertyPrefix)
+}
+
+// FreeBounds returns the position and size of an object in a "Free" layout.
+// An object that has not been placed is at the top left, at its minimum size.
+func FreeBounds(o fyne.CanvasObject, d DefyneContext) (fyne.Position, fyne.Size) {
+	props := d.Metadata()[o]
+	pos := fyne.NewPos(freeValue(props, FreeX, 0), freeValue(props, FreeY, 0))
+
+	minSize := o.MinSize()
+	size := fyne.NewSize(freeValue(props, FreeWidth, minSize.Width), freeValue(props, FreeHeight, minSize.Height))
+	return pos, size
+}
+
+// SetFreeBounds stores the position and size of an object in a "Free" layout.
+func SetFreeBounds(o fyne.CanvasObject, d DefyneContext, pos fyne.Position, size fyne.Size) {
+	props := d.Metadata()[o]
+	if props == nil {
+		props = make(map[string]string)
+		d.Metadata()[o] = props
+	}
+
+	props[FreeX] = formatFree(pos.X)
+	props[FreeY] = formatFree(pos.Y)
+	props[FreeWidth] = formatFree(size.Width)
+	props[FreeHeight] = formatFree(size.Height)
+}
+
+// ClearFreeBounds removes the position and size of an object that is no longer in a "Free" layout.
+func ClearFreeBounds(o fyne.CanvasObject, d DefyneContext) {
+	for _, k := range []string{FreeX, FreeY, FreeWidth, FreeHeight} {
+		delete(d.Metadata()[o], k)
+	}
+}
+
+// SnapGrid returns the grid spacing that children of a "Free" container snap to, or 0 if they do not snap.
+func SnapGrid(c *fyne.Container, d DefyneContext) float32 {
+	return freeValue(d.Metadata()[c], FreeSnapGrid, defaultSnapGrid)
+}
+
+// freeLayout places objects at the position and size stored in their metadata, previewing a container without a layout.
+type freeLayout struct {
+	d DefyneContext
+}
+
+func (f *freeLayout) Layout(objs []fyne.CanvasObject, _ fyne.Size) {
+	for _, o := range objs {
+		pos, size := FreeBounds(o, f.d)
+		o.Move(pos)
+		o.Resize(size)
+	}
+}
+
+// MinSize is zero, like the container without layout that the design generates.
+func (f *freeLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+func freeEdit(c *fyne.Container, d DefyneContext, onchanged func()) []*widget.FormItem {
+	props := d.Metadata()[c]
+	grid := widget.NewEntry()
+	grid.SetText(formatFree(SnapGrid(c, d)))
+	grid.Validator = func(s string) error {
+		if f, err := strconv.ParseFloat(s, 32); err != nil || f < 0 {
+			return errors.New("grid must be a number, 0 to turn off snapping")
+		}
+		return nil
+	}
+	grid.OnChanged = func(s string) {
+		if grid.Validate() != nil {
+			return
+		}
+
+		props[FreeSnapGrid] = s
+		onchanged()
+	}
+	return []*widget.FormItem{widget.NewFormItem("Snap Grid", grid)}
+}
+
+func freeGoText(c *fyne.Container, d DefyneContext, defs map[string]string) string {
+	str := &strings.Builder{}
+	str.WriteString("func() *fyne.Container {\n\t\tfree := container.NewWithoutLayout(")
+	writeGoStringExcluding(str, nil, d, defs, c.Objects...)
+	str.WriteString(")\n")
+	for i, o := range c.Objects {
+		pos, size := FreeBounds(o, d)
+		fmt.Fprintf(str, "\t\tfree.Objects[%d].Move(fyne.NewPos(%s, %s))\n", i, formatFree(pos.X), formatFree(pos.Y))
+		fmt.Fprintf(str, "\t\tfree.Objects[%d].Resize(fyne.NewSize(%s, %s))\n", i,
+			formatFree(size.Width), formatFree(size.Height))
+	}
+	str.WriteString("\t\treturn free\n\t}()")
+	return str.String()
+}
+
+func formatFree(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+func freeValue(props map[string]string, key string, fallback float32) float32 {
+	f, err := strconv.ParseFloat(props[key], 32)
+	if err != nil {
+		return fallback
+	}
+	return float32(f)
+}
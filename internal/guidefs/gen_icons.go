@@ -0,0 +1,127 @@
+//go:build ignore
+
+// This program generates icons_generated.go, the list of icons provided by the Fyne theme package.
+// It is run by "go generate" and looks up the theme package source using "go list",
+// alternatively the theme source directory can be passed as the only argument.
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+const outputFile = "icons_generated.go"
+
+// categories are matched in order against the start of each icon name, the first match wins.
+var categories = []struct {
+	name     string
+	prefixes []string
+}{
+	{"Account", []string{"Account", "Login", "Logout"}},
+	{"Actions", []string{"Cancel", "Confirm", "Content", "Delete", "Search"}},
+	{"Color", []string{"Color"}},
+	{"Files", []string{"Computer", "Document", "Download", "File", "Folder", "Storage", "Upload"}},
+	{"Inputs", []string{"CheckButton", "RadioButton"}},
+	{"Mail", []string{"Mail"}},
+	{"Media", []string{"Media", "Volume"}},
+	{"Navigation", []string{"Grid", "History", "Home", "List", "Menu", "More", "Move", "Navigate", "Settings"}},
+	{"Status", []string{"BrokenImage", "Error", "Help", "Info", "Question", "Warning"}},
+	{"View", []string{"View", "Visibility", "Window", "Zoom"}},
+}
+
+func main() {
+	dir := ""
+	if len(os.Args) > 1 {
+		dir = os.Args[1]
+	} else {
+		out, err := exec.Command("go", "list", "-f", "{{.Dir}}", "fyne.io/fyne/v2/theme").Output()
+		if err != nil {
+			log.Fatalln("Failed to find the Fyne theme package:", err)
+		}
+		dir = strings.TrimSpace(string(out))
+	}
+
+	names, err := iconFuncs(dir)
+	if err != nil {
+		log.Fatalln("Failed to parse the Fyne theme package:", err)
+	}
+
+	str := &strings.Builder{}
+	str.WriteString("// Code generated by gen_icons.go; DO NOT EDIT.\n\n")
+	str.WriteString("package guidefs\n\nimport \"fyne.io/fyne/v2/theme\"\n\n")
+	str.WriteString("// themeIcons lists every icon provided by the theme package with its category.\n")
+	str.WriteString("var themeIcons = []iconInfo{\n")
+	for _, n := range names {
+		str.WriteString(fmt.Sprintf("\t{%q, %q, theme.%s},\n", n, category(n), n))
+	}
+	str.WriteString("}\n")
+
+	code, err := format.Source([]byte(str.String()))
+	if err != nil {
+		log.Fatalln("Failed to format generated code:", err)
+	}
+	if err = os.WriteFile(outputFile, code, 0644); err != nil {
+		log.Fatalln("Failed to write", outputFile, err)
+	}
+}
+
+func category(name string) string {
+	for _, c := range categories {
+		for _, p := range c.prefixes {
+			if strings.HasPrefix(name, p) {
+				return c.name
+			}
+		}
+	}
+
+	return "Other"
+}
+
+// iconFuncs returns the names of exported functions in the package at dir that take no parameters,
+// return a single fyne.Resource and are not deprecated.
+func iconFuncs(dir string) ([]string, error) {
+	files := func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, files, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := pkgs["theme"]
+	if !ok {
+		return nil, fmt.Errorf("no theme package found in %s", dir)
+	}
+
+	var names []string
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() || !strings.HasSuffix(fn.Name.Name, "Icon") {
+				continue
+			}
+			if fn.Type.Params.NumFields() != 0 || fn.Type.Results.NumFields() != 1 {
+				continue
+			}
+			if sel, ok := fn.Type.Results.List[0].Type.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Resource" {
+				continue
+			}
+			if fn.Doc != nil && strings.Contains(fn.Doc.Text(), "Deprecated:") {
+				continue
+			}
+
+			names = append(names, fn.Name.Name)
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
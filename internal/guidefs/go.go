@@ -16,17 +16,37 @@ func GoString(clazz string, obj fyne.CanvasObject, c DefyneContext, defs map[str
 	}
 
 	if fn := info.Gostring; fn != nil {
-		return fn(obj, c, defs)
+		return eventsGoString(clazz, obj, c, defs, fn(obj, c, defs))
 	}
 
 	buf := bytes.Buffer{}
 	fallbackPrint(reflect.ValueOf(obj), &buf)
-	return widgetRef(c.Metadata()[obj], defs, buf.String())
+	return eventsGoString(clazz, obj, c, defs, widgetRef(c.Metadata()[obj], defs, buf.String()))
+}
+
+// receiver is the name of the design in its generated methods, which holds the named objects and handler methods.
+const receiver = "g"
+
+// fieldRef returns the code that refers to a named object of the design from its generated methods.
+func fieldRef(name string) string {
+	return receiver + "." + name
+}
+
+// handlerRef returns the code that refers to a handler method of the design from its generated methods.
+func handlerRef(name string) string {
+	return receiver + "." + name
 }
 
 // fallbackPrint is derived from printValue in the BSD licensed Go source code at: src/fmt/print.go.
 // We use it here as a fallback Go printer that handles only exported fields.
 func fallbackPrint(value reflect.Value, buf *bytes.Buffer) {
+	if value.IsValid() && value.CanInterface() {
+		if c, ok := value.Interface().(*themeColor); ok {
+			buf.WriteString(c.goString())
+			return
+		}
+	}
+
 	switch value.Kind() {
 	case reflect.Struct:
 		t := value.Type()
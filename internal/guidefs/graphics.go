@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"image/color"
 	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -29,10 +31,10 @@ func initGraphics() {
 				rect.StrokeColor = color.Black
 				return rect
 			},
-			Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+			Edit: func(obj fyne.CanvasObject, ctx DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 				r := obj.(*canvas.Circle)
 				return []*widget.FormItem{
-					widget.NewFormItem("Fill", newColorButton(r.FillColor, func(c color.Color) {
+					widget.NewFormItem("Fill", newColorButton(obj, "FillColor", r.FillColor, ctx, func(c color.Color) {
 						r.FillColor = c
 						r.Refresh()
 						onchanged()
@@ -42,15 +44,147 @@ func initGraphics() {
 						r.Refresh()
 						onchanged()
 					})),
-					widget.NewFormItem("Color", newColorButton(r.StrokeColor, func(c color.Color) {
+					widget.NewFormItem("Color", newColorButton(obj, "StrokeColor", r.StrokeColor, ctx, func(c color.Color) {
 						r.StrokeColor = c
 						r.Refresh()
 						onchanged()
 					})),
 				}
 			},
+			Packages: func(obj fyne.CanvasObject, _ DefyneContext) []string {
+				return colorPackages(obj)
+			},
+		},
+		"*canvas.Image": {
+			Name: "Image",
+			Create: func(DefyneContext) fyne.CanvasObject {
+				img := canvas.NewImageFromResource(theme.FileImageIcon())
+				img.FillMode = canvas.ImageFillContain
+				img.SetMinSize(fyne.NewSquareSize(defaultImageSize))
+				return img
+			},
+			Edit: func(obj fyne.CanvasObject, c DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+				img := obj.(*canvas.Image)
+				props := c.Metadata()[obj]
+
+				res := widget.NewSelect(append([]string{noImageLabel}, projectImages(c)...), nil)
+				res.Selected = props[ImageResourceProperty]
+				if res.Selected == "" {
+					res.Selected = noImageLabel
+				}
+				res.OnChanged = func(path string) {
+					if path == noImageLabel {
+						path = ""
+					}
+					props[ImageResourceProperty] = path
+					applyImageProperties(img, c)
+					onchanged()
+				}
+
+				fill := widget.NewSelect(imageFills, nil)
+				if int(img.FillMode) < len(imageFills) {
+					fill.Selected = imageFills[img.FillMode]
+				}
+				fill.OnChanged = func(string) {
+					img.FillMode = canvas.ImageFill(fill.SelectedIndex())
+					img.Refresh()
+					onchanged()
+				}
+				scale := widget.NewSelect(imageScales, nil)
+				if int(img.ScaleMode) < len(imageScales) {
+					scale.Selected = imageScales[img.ScaleMode]
+				}
+				scale.OnChanged = func(string) {
+					img.ScaleMode = canvas.ImageScale(scale.SelectedIndex())
+					img.Refresh()
+					onchanged()
+				}
+
+				minSize := imageMinSize(props)
+				return []*widget.FormItem{
+					widget.NewFormItem("Resource", res),
+					widget.NewFormItem("Fill", fill),
+					widget.NewFormItem("Scale", scale),
+					widget.NewFormItem("Translucency", newSliderButton(img.Translucency*100, 0, 100, func(f float64) {
+						img.Translucency = f / 100
+						img.Refresh()
+						onchanged()
+					})),
+					widget.NewFormItem("Min Width", newSliderButton(float64(minSize.Width), 0, 512, func(f float64) {
+						props["minWidth"] = strconv.Itoa(int(f))
+						applyImageProperties(img, c)
+						onchanged()
+					})),
+					widget.NewFormItem("Min Height", newSliderButton(float64(minSize.Height), 0, 512, func(f float64) {
+						props["minHeight"] = strconv.Itoa(int(f))
+						applyImageProperties(img, c)
+						onchanged()
+					})),
+				}
+			},
+			Gostring: func(obj fyne.CanvasObject, c DefyneContext, defs map[string]string) string {
+				props := c.Metadata()[obj]
+				img := obj.(*canvas.Image)
+
+				str := &strings.Builder{}
+				str.WriteString("&canvas.Image{")
+				if path := props[ImageResourceProperty]; path != "" {
+					str.WriteString("Resource: " + BundleName(path) + ", ")
+				}
+				if int(img.FillMode) < len(imageFills) {
+					str.WriteString("FillMode: canvas.ImageFill" + imageFills[img.FillMode] + ", ")
+				}
+				if int(img.ScaleMode) < len(imageScales) {
+					str.WriteString("ScaleMode: canvas.ImageScale" + imageScales[img.ScaleMode] + ", ")
+				}
+				str.WriteString(fmt.Sprintf("Translucency: %g}", img.Translucency))
+
+				minSize := imageMinSize(props)
+				if minSize.Width == 0 && minSize.Height == 0 {
+					return widgetRef(props, defs, str.String())
+				}
+				return widgetRef(props, defs, fmt.Sprintf(`func() *canvas.Image {
+		img := %s
+		img.SetMinSize(fyne.NewSize(%g, %g))
+		return img
+	}()`, str.String(), minSize.Width, minSize.Height))
+			},
 			Packages: func(_ fyne.CanvasObject, _ DefyneContext) []string {
-				return []string{"canvas", "image/color"}
+				return []string{"canvas"}
+			},
+			Restore: func(obj fyne.CanvasObject, c DefyneContext) {
+				applyImageProperties(obj.(*canvas.Image), c)
+			},
+		},
+		"*canvas.Line": {
+			Name: "Line",
+			Create: func(DefyneContext) fyne.CanvasObject {
+				line := canvas.NewLine(color.NRGBA{A: 0xff})
+				line.StrokeWidth = 2
+				return line
+			},
+			Edit: func(obj fyne.CanvasObject, ctx DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+				l := obj.(*canvas.Line)
+				return []*widget.FormItem{
+					widget.NewFormItem("Stroke", newSliderButton(float64(l.StrokeWidth), 0, 32, func(f float64) {
+						l.StrokeWidth = float32(f)
+						l.Refresh()
+						onchanged()
+					})),
+					widget.NewFormItem("Color", newColorButton(obj, "StrokeColor", l.StrokeColor, ctx, func(c color.Color) {
+						l.StrokeColor = c
+						l.Refresh()
+						onchanged()
+					})),
+				}
+			},
+			Gostring: func(obj fyne.CanvasObject, c DefyneContext, defs map[string]string) string {
+				l := obj.(*canvas.Line)
+				return widgetRef(c.Metadata()[obj], defs,
+					fmt.Sprintf("&canvas.Line{StrokeColor: %s, StrokeWidth: %g}", goColor(l.StrokeColor), l.StrokeWidth))
+			},
+			Packages: func(obj fyne.CanvasObject, _ DefyneContext) []string {
+				return colorPackages(obj)
 			},
 		},
 		"*canvas.LinearGradient": {
@@ -58,7 +192,7 @@ func initGraphics() {
 			Create: func(DefyneContext) fyne.CanvasObject {
 				return &canvas.LinearGradient{StartColor: color.White}
 			},
-			Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+			Edit: func(obj fyne.CanvasObject, ctx DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 				r := obj.(*canvas.LinearGradient)
 				angleSlide := widget.NewSlider(0, 360)
 				angleSlide.Step = 90
@@ -68,12 +202,12 @@ func initGraphics() {
 					onchanged()
 				}
 				return []*widget.FormItem{
-					widget.NewFormItem("Start", newColorButton(r.StartColor, func(c color.Color) {
+					widget.NewFormItem("Start", newColorButton(obj, "StartColor", r.StartColor, ctx, func(c color.Color) {
 						r.StartColor = c
 						r.Refresh()
 						onchanged()
 					})),
-					widget.NewFormItem("End", newColorButton(r.EndColor, func(c color.Color) {
+					widget.NewFormItem("End", newColorButton(obj, "EndColor", r.EndColor, ctx, func(c color.Color) {
 						r.EndColor = c
 						r.Refresh()
 						onchanged()
@@ -81,8 +215,8 @@ func initGraphics() {
 					widget.NewFormItem("Angle", angleSlide),
 				}
 			},
-			Packages: func(_ fyne.CanvasObject, _ DefyneContext) []string {
-				return []string{"canvas", "image/color"}
+			Packages: func(obj fyne.CanvasObject, _ DefyneContext) []string {
+				return colorPackages(obj)
 			},
 		},
 		"*canvas.RadialGradient": {
@@ -90,24 +224,101 @@ func initGraphics() {
 			Create: func(DefyneContext) fyne.CanvasObject {
 				return &canvas.RadialGradient{StartColor: color.White}
 			},
-			Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+			Edit: func(obj fyne.CanvasObject, ctx DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 				r := obj.(*canvas.RadialGradient)
 				return []*widget.FormItem{
-					widget.NewFormItem("Start", newColorButton(r.StartColor, func(c color.Color) {
+					widget.NewFormItem("Start", newColorButton(obj, "StartColor", r.StartColor, ctx, func(c color.Color) {
 						r.StartColor = c
 						r.Refresh()
 						onchanged()
 					})),
-					widget.NewFormItem("End", newColorButton(r.EndColor, func(c color.Color) {
+					widget.NewFormItem("End", newColorButton(obj, "EndColor", r.EndColor, ctx, func(c color.Color) {
 						r.EndColor = c
 						r.Refresh()
 						onchanged()
 					})),
 				}
 			},
-			Packages: func(_ fyne.CanvasObject, _ DefyneContext) []string {
+			Packages: func(obj fyne.CanvasObject, _ DefyneContext) []string {
+				return colorPackages(obj)
+			},
+		},
+		"*canvas.Raster": {
+			Name: "Raster",
+			Create: func(DefyneContext) fyne.CanvasObject {
+				r := canvas.NewRasterWithPixels(rasterPreview)
+				r.SetMinSize(fyne.NewSquareSize(defaultImageSize))
+				return r
+			},
+			Edit: func(obj fyne.CanvasObject, c DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+				r := obj.(*canvas.Raster)
+				props := c.Metadata()[obj]
+
+				handler := widget.NewEntry()
+				handler.SetPlaceHolder("Method name")
+				handler.SetText(strings.TrimPrefix(props[rasterPixels], handlerRef("")))
+				handler.Validator = ValidateIdentifier
+				handler.OnChanged = func(s string) {
+					if handler.Validate() != nil {
+						return
+					}
+					if s == "" {
+						delete(props, rasterPixels)
+					} else {
+						props[rasterPixels] = handlerRef(s)
+					}
+					onchanged()
+				}
+
+				minSize := imageMinSize(props)
+				return []*widget.FormItem{
+					{Text: "Pixels", Widget: handler, HintText: "Method on the GUI type, func" + rasterSignature},
+					widget.NewFormItem("Translucency", newSliderButton(r.Translucency*100, 0, 100, func(f float64) {
+						r.Translucency = f / 100
+						r.Refresh()
+						onchanged()
+					})),
+					widget.NewFormItem("Min Width", newSliderButton(float64(minSize.Width), 0, 512, func(f float64) {
+						props["minWidth"] = strconv.Itoa(int(f))
+						r.SetMinSize(imageMinSize(props))
+						r.Refresh()
+						onchanged()
+					})),
+					widget.NewFormItem("Min Height", newSliderButton(float64(minSize.Height), 0, 512, func(f float64) {
+						props["minHeight"] = strconv.Itoa(int(f))
+						r.SetMinSize(imageMinSize(props))
+						r.Refresh()
+						onchanged()
+					})),
+				}
+			},
+			Gostring: func(obj fyne.CanvasObject, c DefyneContext, defs map[string]string) string {
+				props := c.Metadata()[obj]
+				r := obj.(*canvas.Raster)
+
+				pixels := "func(_, _, _, _ int) color.Color { return color.Transparent }"
+				if _, ok := handlerName(props[rasterPixels]); ok {
+					pixels = props[rasterPixels]
+				}
+				str := &strings.Builder{}
+				str.WriteString("func() *canvas.Raster {\n\t\tr := canvas.NewRasterWithPixels(" + pixels + ")\n")
+				if r.Translucency != 0 {
+					str.WriteString(fmt.Sprintf("\t\tr.Translucency = %g\n", r.Translucency))
+				}
+				minSize := imageMinSize(props)
+				str.WriteString(fmt.Sprintf("\t\tr.SetMinSize(fyne.NewSize(%g, %g))\n\t\treturn r\n\t}()",
+					minSize.Width, minSize.Height))
+				return widgetRef(props, defs, str.String())
+			},
+			Packages: func(obj fyne.CanvasObject, c DefyneContext) []string {
+				if _, ok := handlerName(c.Metadata()[obj][rasterPixels]); ok {
+					return []string{"canvas"}
+				}
 				return []string{"canvas", "image/color"}
 			},
+			Restore: func(obj fyne.CanvasObject, c DefyneContext) {
+				obj.(*canvas.Raster).SetMinSize(imageMinSize(c.Metadata()[obj]))
+			},
 		},
 		"*canvas.Rectangle": {
 			Name: "Rectangle",
@@ -116,10 +327,10 @@ func initGraphics() {
 				rect.StrokeColor = color.Black
 				return rect
 			},
-			Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+			Edit: func(obj fyne.CanvasObject, ctx DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 				r := obj.(*canvas.Rectangle)
 				return []*widget.FormItem{
-					widget.NewFormItem("Fill", newColorButton(r.FillColor, func(c color.Color) {
+					widget.NewFormItem("Fill", newColorButton(obj, "FillColor", r.FillColor, ctx, func(c color.Color) {
 						r.FillColor = c
 						r.Refresh()
 						onchanged()
@@ -134,15 +345,15 @@ func initGraphics() {
 						r.Refresh()
 						onchanged()
 					})),
-					widget.NewFormItem("Color", newColorButton(r.StrokeColor, func(c color.Color) {
+					widget.NewFormItem("Color", newColorButton(obj, "StrokeColor", r.StrokeColor, ctx, func(c color.Color) {
 						r.StrokeColor = c
 						r.Refresh()
 						onchanged()
 					})),
 				}
 			},
-			Packages: func(_ fyne.CanvasObject, _ DefyneContext) []string {
-				return []string{"canvas", "image/color"}
+			Packages: func(obj fyne.CanvasObject, _ DefyneContext) []string {
+				return colorPackages(obj)
 			},
 		},
 		"*canvas.Text": {
@@ -151,7 +362,7 @@ func initGraphics() {
 				rect := canvas.NewText("Text", color.Black)
 				return rect
 			},
-			Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+			Edit: func(obj fyne.CanvasObject, ctx DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 				t := obj.(*canvas.Text)
 				e := widget.NewEntry()
 				e.SetText(t.Text)
@@ -182,7 +393,7 @@ func initGraphics() {
 
 				return []*widget.FormItem{
 					widget.NewFormItem("Text", e),
-					widget.NewFormItem("Color", newColorButton(t.Color, func(c color.Color) {
+					widget.NewFormItem("Color", newColorButton(obj, "Color", t.Color, ctx, func(c color.Color) {
 						t.Color = c
 						t.Refresh()
 						onchanged()
@@ -197,8 +408,8 @@ func initGraphics() {
 					widget.NewFormItem("Monospace", mono),
 				}
 			},
-			Packages: func(_ fyne.CanvasObject, _ DefyneContext) []string {
-				return []string{"canvas", "image/color"}
+			Packages: func(obj fyne.CanvasObject, _ DefyneContext) []string {
+				return colorPackages(obj)
 			},
 		},
 	}
@@ -206,26 +417,123 @@ func initGraphics() {
 	GraphicsNames = extractNames(Graphics)
 }
 
+// ImageResourceProperty is the metadata key of an Image that stores the path of its file, relative to the project.
+const ImageResourceProperty = "resource"
+
+const (
+	defaultImageSize = 64
+	noImageLabel     = "(No Image)"
+
+	// rasterPixels is the metadata key of a Raster that stores the handler method generating its pixels
+	rasterPixels    = "pixels"
+	rasterSignature = "(x, y, w, h int) color.Color"
+)
+
+var (
+	imageFills  = []string{"Stretch", "Contain", "Original"}
+	imageScales = []string{"Smooth", "Pixels", "Fastest"}
+)
+
+func applyImageProperties(img *canvas.Image, c DefyneContext) {
+	props := c.Metadata()[img]
+
+	img.Resource = ProjectResource(c, props[ImageResourceProperty])
+	if img.Resource == nil {
+		img.Resource = theme.FileImageIcon()
+	}
+	img.SetMinSize(imageMinSize(props))
+	img.Refresh()
+}
+
+// rasterPreview draws a Raster in the builder, where the handler that generates its pixels cannot be called.
+func rasterPreview(x, y, _, _ int) color.Color {
+	if (x/8+y/8)%2 == 0 {
+		return color.Gray{Y: 0xcc}
+	}
+	return color.Gray{Y: 0xee}
+}
+
+func imageMinSize(props map[string]string) fyne.Size {
+	size := fyne.NewSquareSize(defaultImageSize)
+	if w, err := strconv.ParseFloat(props["minWidth"], 32); err == nil {
+		size.Width = float32(w)
+	}
+	if h, err := strconv.ParseFloat(props["minHeight"], 32); err == nil {
+		size.Height = float32(h)
+	}
+	return size
+}
+
+func goColor(c color.Color) string {
+	if c == nil {
+		return "nil"
+	}
+	if th, ok := c.(*themeColor); ok {
+		return th.goString()
+	}
+
+	return fmt.Sprintf("%#v", color.NRGBAModel.Convert(c))
+}
+
 // TODO tidy the API and move to a widget package
 
-func newColorButton(c color.Color, fn func(color.Color)) fyne.CanvasObject {
+func newColorButton(obj fyne.CanvasObject, field string, c color.Color, ctx DefyneContext, fn func(color.Color)) fyne.CanvasObject {
 	// TODO get the window passed in somehow
 	w := fyne.CurrentApp().Driver().AllWindows()[0]
+	props := ctx.Metadata()[obj]
+	key := themeColorPrefix + field
 
 	input := widget.NewEntry()
 	input.SetText(formatColor(c))
-	preview := newColorTapper(c, func(col color.Color) {
+	names := widget.NewSelect(append([]string{customColorLabel}, themeColorLabels...), nil)
+	names.Selected = customColorLabel
+	if th, ok := c.(*themeColor); ok {
+		names.Selected = themeColorLabel(th.name)
+		input.Disable()
+	}
+
+	var preview *colorTapper
+	preview = newColorTapper(c, func(col color.Color) {
+		delete(props, key)
+		names.Selected = customColorLabel
+		names.Refresh()
+		input.Enable()
+
 		raw := formatColor(col)
 		input.SetText(raw)
+		preview.setColor(col)
 		fn(col)
 	}, w)
 
 	input.OnChanged = func(raw string) {
+		if props[key] != "" {
+			return // showing the current value of a theme colour
+		}
+
 		c := parseColor(raw)
 		preview.setColor(c)
 		fn(c)
 	}
-	return container.NewBorder(nil, nil, preview, nil, input)
+	names.OnChanged = func(label string) {
+		name, ok := themeColorNames[label]
+		if !ok {
+			delete(props, key)
+			input.Enable()
+
+			c := parseColor(input.Text)
+			preview.setColor(c)
+			fn(c)
+			return
+		}
+
+		props[key] = string(name)
+		c := newThemeColor(name, ctx)
+		input.SetText(formatColor(c))
+		input.Disable()
+		preview.setColor(c)
+		fn(c)
+	}
+	return container.NewBorder(nil, nil, preview, names, input)
 }
 
 type colorTapper struct {
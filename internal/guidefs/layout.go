@@ -16,7 +16,7 @@ import (
 
 type layoutInfo struct {
 	Create func(*fyne.Container, DefyneContext) fyne.Layout
-	Edit   func(*fyne.Container, DefyneContext) []*widget.FormItem
+	Edit   func(*fyne.Container, DefyneContext, func()) []*widget.FormItem
 	goText func(*fyne.Container, DefyneContext, map[string]string) string
 }
 
@@ -54,7 +54,7 @@ var (
 
 				return layout.NewBorderLayout(t, b, l, r)
 			},
-			func(c *fyne.Container, d DefyneContext) []*widget.FormItem {
+			func(c *fyne.Container, d DefyneContext, onchanged func()) []*widget.FormItem {
 				props := d.Metadata()[c]
 				topNum := props["top"]
 				topID, _ := strconv.Atoi(topNum)
@@ -129,6 +129,7 @@ var (
 
 					c.Layout = layout.NewBorderLayout(t, b, l, r)
 					c.Refresh()
+					onchanged()
 				}
 				top.OnChanged = change
 				bottom.OnChanged = change
@@ -207,6 +208,13 @@ var (
 			nil,
 			nil,
 		},
+		"Free": {
+			func(_ *fyne.Container, d DefyneContext) fyne.Layout {
+				return &freeLayout{d: d}
+			},
+			freeEdit,
+			freeGoText,
+		},
 		"Grid": {
 			func(c *fyne.Container, d DefyneContext) fyne.Layout {
 				props := d.Metadata()[c]
@@ -229,7 +237,7 @@ var (
 				}
 				return layout.NewGridLayoutWithColumns(int(num))
 			},
-			func(c *fyne.Container, d DefyneContext) []*widget.FormItem {
+			func(c *fyne.Container, d DefyneContext, onchanged func()) []*widget.FormItem {
 				props := d.Metadata()[c]
 				rowCol := props["grid_type"]
 				if rowCol == "" {
@@ -261,6 +269,7 @@ var (
 						c.Layout = layout.NewGridLayoutWithColumns(int(num))
 					}
 					c.Refresh()
+					onchanged()
 				}
 				cols.OnChanged = change
 				vert.OnChanged = change
@@ -318,7 +327,7 @@ var (
 
 				return layout.NewGridWrapLayout(fyne.NewSize(float32(w), float32(h)))
 			},
-			func(c *fyne.Container, d DefyneContext) []*widget.FormItem {
+			func(c *fyne.Container, d DefyneContext, onchanged func()) []*widget.FormItem {
 				props := d.Metadata()[c]
 				width := props["width"]
 				if width == "" {
@@ -353,6 +362,7 @@ var (
 					props["height"] = heightEnt.Text
 					c.Layout = layout.NewGridWrapLayout(fyne.NewSize(float32(w), float32(h)))
 					c.Refresh()
+					onchanged()
 				}
 				widthEnt.OnChanged = change
 				heightEnt.OnChanged = change
@@ -429,7 +439,7 @@ var (
 				}
 				return layout.NewCustomPaddedLayout(t, b, l, r)
 			},
-			func(c *fyne.Container, d DefyneContext) []*widget.FormItem {
+			func(c *fyne.Container, d DefyneContext, onchanged func()) []*widget.FormItem {
 				props := d.Metadata()[c]
 				pad := theme.Padding()
 				padStr := strconv.FormatFloat(float64(pad), 'f', -2, 64)
@@ -498,6 +508,7 @@ var (
 					props["right"] = rightEnt.Text
 					c.Layout = layout.NewCustomPaddedLayout(t, b, l, r)
 					c.Refresh()
+					onchanged()
 				}
 				topEnt.OnChanged = change
 				bottomEnt.OnChanged = change
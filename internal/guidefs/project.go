@@ -0,0 +1,28 @@
+package guidefs
+
+// projectData holds the custom layouts, styles and strings that are registered for a project.
+// Each project has its own, so that designs from different projects can be edited at the same time.
+type projectData struct {
+	layouts map[string]CustomLayout
+	styles  map[string]Style
+	strings map[string]string
+}
+
+// projects maps the URI of a project root, or "" for contexts without a project, to its registered data.
+var projects = map[string]*projectData{}
+
+// projectFor returns the data registered for the project of the context, creating it if needed.
+func projectFor(c DefyneContext) *projectData {
+	key := ""
+	if p, ok := c.(ProjectContext); ok && p.ProjectRoot() != nil {
+		key = p.ProjectRoot().String()
+	}
+
+	data, ok := projects[key]
+	if !ok {
+		data = &projectData{layouts: map[string]CustomLayout{}, styles: map[string]Style{},
+			strings: map[string]string{}}
+		projects[key] = data
+	}
+	return data
+}
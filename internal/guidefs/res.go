@@ -1,7 +1,12 @@
 package guidefs
 
 import (
+	"sort"
+	"strings"
+	"unicode"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
 )
 
 type jsonResource struct {
@@ -34,3 +39,105 @@ func IconName(res fyne.Resource) string {
 
 	return ret
 }
+
+// BundleName returns the variable name that `fyne bundle` generates for a file in the project.
+func BundleName(path string) string {
+	name := path
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	str := &strings.Builder{}
+	str.WriteString("resource")
+	upper := true
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			upper = false
+		} else {
+			upper = true
+		}
+
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			str.WriteRune(r)
+		}
+	}
+	return str.String()
+}
+
+func projectImages(c DefyneContext) []string {
+	p, ok := c.(ProjectContext)
+	if !ok || p.ProjectRoot() == nil {
+		return nil
+	}
+
+	var images []string
+	root := p.ProjectRoot()
+	var walk func(fyne.URI)
+	walk = func(dir fyne.URI) {
+		items, err := storage.List(dir)
+		if err != nil {
+			return
+		}
+
+		for _, u := range items {
+			if u.Name()[0] == '.' {
+				continue
+			}
+			if ok, _ := storage.CanList(u); ok {
+				walk(u)
+				continue
+			}
+
+			switch strings.ToLower(u.Extension()) {
+			case ".png", ".jpg", ".jpeg", ".svg":
+				images = append(images, projectPath(root, u))
+			}
+		}
+	}
+	walk(root)
+
+	sort.Strings(images)
+	return images
+}
+
+// ProjectResource loads a file from its path relative to the project of the context, or returns nil if it fails.
+func ProjectResource(c DefyneContext, path string) fyne.Resource {
+	u := projectURI(c, path)
+	if u == nil {
+		return nil
+	}
+
+	res, err := storage.LoadResourceFromURI(u)
+	if err != nil {
+		fyne.LogError("Failed to load project resource "+path, err)
+		return nil
+	}
+	return res
+}
+
+// projectURI returns the location of a file from its path relative to the project, or nil if it is not known.
+func projectURI(c DefyneContext, path string) fyne.URI {
+	p, ok := c.(ProjectContext)
+	if !ok || p.ProjectRoot() == nil || path == "" {
+		return nil
+	}
+
+	u := p.ProjectRoot()
+	for _, elem := range strings.Split(path, "/") {
+		var err error
+		u, err = storage.Child(u, elem)
+		if err != nil {
+			fyne.LogError("Failed to find project resource "+path, err)
+			return nil
+		}
+	}
+	return u
+}
+
+// projectPath returns the path of a file in the project, relative to the project root, using "/" separators.
+func projectPath(root, u fyne.URI) string {
+	return strings.TrimPrefix(u.Path()[len(root.Path()):], "/")
+}
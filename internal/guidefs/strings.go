@@ -0,0 +1,247 @@
+package guidefs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// StringsFile is the name of the file, in the project root, that maps string keys to the text of a project.
+const StringsFile = "strings.defyne.json"
+
+// StringPropertyPrefix starts the metadata keys, such as "string.Text", that link a text field to a string key.
+const StringPropertyPrefix = "string."
+
+const noStringLabel = "(None)"
+
+var (
+	stringKeyRegexp = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9_.-]*$")
+
+	// stringFields lists the text fields, of each type, that can use a string from the table
+	stringFields = map[string][]string{
+		"*widget.Button":    {"Text"},
+		"*widget.Check":     {"Text"},
+		"*widget.Entry":     {"Text", "PlaceHolder"},
+		"*widget.Hyperlink": {"Text"},
+		"*widget.Label":     {"Text"},
+	}
+)
+
+// ValidateStringKey returns an error if the key cannot be used in the string table.
+func ValidateStringKey(key string) error {
+	if !stringKeyRegexp.MatchString(key) {
+		return errors.New("keys start with a letter and contain letters, numbers, '_', '.' or '-'")
+	}
+	return nil
+}
+
+// StringKeys returns the keys of the string table of the project of the context, in order.
+func StringKeys(c DefyneContext) []string {
+	table := projectFor(c).strings
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// StringValue returns the text of a key in the string table of the project of the context, and whether it was found.
+func StringValue(c DefyneContext, key string) (string, bool) {
+	s, ok := projectFor(c).strings[key]
+	return s, ok
+}
+
+// StringConstName returns the name of the Go constant generated for a string key, such as "strWelcomeTitle"
+// for "welcome.title".
+func StringConstName(key string) string {
+	name := &strings.Builder{}
+	name.WriteString("str")
+	upper := true
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		name.WriteRune(r)
+	}
+	return name.String()
+}
+
+// SetStrings replaces the string table of the project of the context.
+func SetStrings(c DefyneContext, table map[string]string) error {
+	consts := make(map[string]string)
+	for k := range table {
+		if err := ValidateStringKey(k); err != nil {
+			return fmt.Errorf("string %q: %w", k, err)
+		}
+
+		name := StringConstName(k)
+		if other, ok := consts[name]; ok {
+			return fmt.Errorf("strings %q and %q would generate the same constant %s", other, k, name)
+		}
+		consts[name] = k
+	}
+
+	strs := make(map[string]string, len(table))
+	for k, v := range table {
+		strs[k] = v
+	}
+	projectFor(c).strings = strs
+	return nil
+}
+
+// LoadStrings reads a string table, in JSON format, and uses it in place of the table of the project of the context.
+func LoadStrings(c DefyneContext, r io.Reader) error {
+	table := make(map[string]string)
+	if err := json.NewDecoder(r).Decode(&table); err != nil {
+		return err
+	}
+
+	return SetStrings(c, table)
+}
+
+// LoadProjectStrings replaces the string table of the project of the context with the one in its StringsFile, if any.
+func LoadProjectStrings(c DefyneContext) {
+	projectFor(c).strings = map[string]string{}
+
+	u := projectFileURI(c, StringsFile)
+	if u == nil {
+		return
+	}
+	if ok, _ := storage.Exists(u); !ok {
+		return
+	}
+
+	r, err := storage.Reader(u)
+	if err != nil {
+		fyne.LogError("Failed to open "+StringsFile, err)
+		return
+	}
+	defer r.Close()
+	if err = LoadStrings(c, r); err != nil {
+		fyne.LogError("Failed to load "+StringsFile, err)
+	}
+}
+
+// SaveProjectStrings writes the string table to the project of the context, and uses it in place of the current table.
+func SaveProjectStrings(c DefyneContext, table map[string]string) error {
+	u := projectFileURI(c, StringsFile)
+	if u == nil {
+		return errors.New("strings can only be saved in a project")
+	}
+	if err := SetStrings(c, table); err != nil {
+		return err
+	}
+
+	w, err := storage.Writer(u)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(projectFor(c).strings)
+	_ = w.Close()
+	return err
+}
+
+// ApplyStrings sets the text fields of the object that are linked to keys in the string table.
+// It returns true if any were set, the caller should refresh the object if it is visible.
+func ApplyStrings(obj fyne.CanvasObject, c DefyneContext) bool {
+	props := c.Metadata()[obj]
+	applied := false
+	for _, field := range stringFields[reflect.TypeOf(obj).String()] {
+		text, ok := projectFor(c).strings[props[StringPropertyPrefix+field]]
+		if !ok {
+			continue
+		}
+
+		if f := reflect.ValueOf(obj).Elem().FieldByName(field); f.CanSet() && f.Kind() == reflect.String {
+			f.SetString(text)
+			applied = true
+		}
+	}
+	return applied
+}
+
+// StringItems returns the form items to link the text fields of an object to the string table, or nil if there are
+// no strings or the object has no text fields that can use them.
+// The onchanged func is called after a string is chosen.
+func StringItems(obj fyne.CanvasObject, c DefyneContext, onchanged func()) []*widget.FormItem {
+	fields := stringFields[reflect.TypeOf(obj).String()]
+	table := projectFor(c).strings
+	if len(table) == 0 || len(fields) == 0 {
+		return nil
+	}
+
+	props := c.Metadata()[obj]
+	if props == nil {
+		props = make(map[string]string)
+		c.Metadata()[obj] = props
+	}
+	var items []*widget.FormItem
+	for _, f := range fields {
+		key := StringPropertyPrefix + f
+		choose := widget.NewSelect(append([]string{noStringLabel}, StringKeys(c)...), nil)
+		choose.Selected = noStringLabel
+		if _, ok := table[props[key]]; ok {
+			choose.Selected = props[key]
+		}
+		choose.OnChanged = func(k string) {
+			if k == noStringLabel {
+				delete(props, key)
+			} else {
+				props[key] = k
+				ApplyStrings(obj, c)
+				obj.Refresh()
+			}
+			onchanged()
+		}
+		items = append(items, widget.NewFormItem(f+" String", choose))
+	}
+	return items
+}
+
+// unlinkString removes the link of a text field to the string table, when the text is edited directly.
+func unlinkString(obj fyne.CanvasObject, c DefyneContext, field string) {
+	delete(c.Metadata()[obj], StringPropertyPrefix+field)
+}
+
+// textGoString returns the Go code for a text field, which is a string constant if it is linked to the string table.
+func textGoString(c DefyneContext, props map[string]string, field, text string) string {
+	if key := props[StringPropertyPrefix+field]; key != "" {
+		if _, ok := projectFor(c).strings[key]; ok {
+			return StringConstName(key)
+		}
+	}
+
+	return "\"" + escapeLabel(text) + "\""
+}
+
+func projectFileURI(c DefyneContext, name string) fyne.URI {
+	p, ok := c.(ProjectContext)
+	if !ok || p.ProjectRoot() == nil {
+		return nil
+	}
+
+	u, err := storage.Child(p.ProjectRoot(), name)
+	if err != nil {
+		return nil
+	}
+	return u
+}
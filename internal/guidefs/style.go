@@ -0,0 +1,338 @@
+package guidefs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// StylesFile is the name of the file, in the project root, that lists the named styles of a project.
+const StylesFile = "styles.defyne.json"
+
+// StyleProperty is the metadata key that names the style an object uses.
+const StyleProperty = "style"
+
+const noStyleLabel = "(None)"
+
+// StyleAlignments are the values that a Style can set for Alignment.
+var StyleAlignments = []string{"Leading", "Center", "Trailing"}
+
+var (
+	importanceType  = reflect.TypeOf(widget.MediumImportance)
+	textAlignType   = reflect.TypeOf(fyne.TextAlignLeading)
+	buttonAlignType = reflect.TypeOf(widget.ButtonAlignCenter)
+	textStyleType   = reflect.TypeOf(fyne.TextStyle{})
+
+	// styledTypes are the objects that generate code for the fields that a style can set
+	styledTypes = map[string]bool{"*widget.Button": true, "*widget.Label": true, "*canvas.Text": true,
+		"*canvas.Rectangle": true, "*canvas.Circle": true}
+
+	// styleColorFields are the colour fields that a style Color is applied to, the first found is used
+	styleColorFields = []string{"Color", "FillColor"}
+)
+
+// Style is a named set of properties that can be shared by objects in all designs of a project.
+// Empty values are not applied, so that the object keeps its own setting.
+type Style struct {
+	Name string
+	// Importance is one of "Medium", "High", "Low", "Danger", "Warning" or "Success".
+	Importance string `json:",omitempty"`
+	// Alignment is one of StyleAlignments.
+	Alignment string          `json:",omitempty"`
+	TextStyle *fyne.TextStyle `json:",omitempty"`
+	// Color is the name of a theme colour, such as "Primary", or a value formatted as #rrggbb or #rrggbbaa.
+	Color    string  `json:",omitempty"`
+	TextSize float32 `json:",omitempty"`
+}
+
+// StyleImportances returns the values that a Style can set for Importance.
+func StyleImportances() []string {
+	return importances
+}
+
+// StyleColors returns the theme colour names that a Style can set for Color.
+func StyleColors() []string {
+	return themeColorLabels
+}
+
+// Styles returns the styles that are registered for the project of the context, ordered by name.
+func Styles(c DefyneContext) []Style {
+	styles := projectFor(c).styles
+	list := make([]Style, 0, len(styles))
+	for _, s := range styles {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// RegisterStyle adds a named style to the project of the context so that objects can use it.
+// Registering a style with the same name as an existing style will replace it.
+func RegisterStyle(c DefyneContext, s Style) error {
+	if s.Name == "" {
+		return errors.New("style requires a name")
+	}
+	if s.Importance != "" && indexOf(importances, s.Importance) == -1 {
+		return fmt.Errorf("style %s has unknown importance %q", s.Name, s.Importance)
+	}
+	if s.Alignment != "" && indexOf(StyleAlignments, s.Alignment) == -1 {
+		return fmt.Errorf("style %s has unknown alignment %q", s.Name, s.Alignment)
+	}
+	if _, ok := themeColorNames[s.Color]; !ok {
+		if err := validateColor(s.Color); err != nil {
+			return fmt.Errorf("style %s colour: %w", s.Name, err)
+		}
+	}
+	if s.TextSize < 0 {
+		return fmt.Errorf("style %s has a negative text size", s.Name)
+	}
+
+	projectFor(c).styles[s.Name] = s
+	return nil
+}
+
+// LoadStyles reads a list of styles in JSON format and registers them for the project of the context.
+func LoadStyles(c DefyneContext, r io.Reader) error {
+	var list []Style
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return err
+	}
+
+	for _, s := range list {
+		if err := RegisterStyle(c, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadProjectStyles replaces the styles of the project of the context with those listed in its StylesFile, if any.
+func LoadProjectStyles(c DefyneContext) {
+	projectFor(c).styles = map[string]Style{}
+
+	u := projectFileURI(c, StylesFile)
+	if u == nil {
+		return
+	}
+	if ok, _ := storage.Exists(u); !ok {
+		return
+	}
+
+	r, err := storage.Reader(u)
+	if err != nil {
+		fyne.LogError("Failed to open "+StylesFile, err)
+		return
+	}
+	defer r.Close()
+	if err = LoadStyles(c, r); err != nil {
+		fyne.LogError("Failed to load "+StylesFile, err)
+	}
+}
+
+// SaveProjectStyles writes the list of styles to the project of the context, and registers them in place of the
+// current styles.
+func SaveProjectStyles(c DefyneContext, list []Style) error {
+	u := projectFileURI(c, StylesFile)
+	if u == nil {
+		return errors.New("styles can only be saved in a project")
+	}
+
+	p := projectFor(c)
+	prev := p.styles
+	p.styles = map[string]Style{}
+	for _, s := range list {
+		if err := RegisterStyle(c, s); err != nil {
+			p.styles = prev
+			return err
+		}
+	}
+
+	w, err := storage.Writer(u)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(Styles(c))
+	_ = w.Close()
+	return err
+}
+
+// ApplyStyle sets the properties of the style that the object's metadata names, if it is registered.
+// It returns true if a style was applied, the caller should refresh the object if it is visible.
+func ApplyStyle(obj fyne.CanvasObject, c DefyneContext) bool {
+	s, ok := projectFor(c).styles[c.Metadata()[obj][StyleProperty]]
+	if !ok || !IsStyleable(obj) {
+		return false
+	}
+
+	v := reflect.ValueOf(obj).Elem()
+	if f := styleField(v, "Importance", importanceType); f.IsValid() && s.Importance != "" {
+		f.SetInt(int64(indexOf(importances, s.Importance)))
+	}
+	if f := v.FieldByName("Alignment"); f.IsValid() && f.CanSet() && s.Alignment != "" {
+		switch f.Type() {
+		case textAlignType:
+			f.SetInt(int64(indexOf(StyleAlignments, s.Alignment)))
+		case buttonAlignType:
+			f.SetInt(int64(buttonAlignment(s.Alignment)))
+		}
+	}
+	if f := styleField(v, "TextStyle", textStyleType); f.IsValid() && s.TextStyle != nil {
+		f.Set(reflect.ValueOf(*s.TextStyle))
+	}
+	if f := styleField(v, "TextSize", reflect.TypeOf(float32(0))); f.IsValid() && s.TextSize > 0 {
+		f.SetFloat(float64(s.TextSize))
+	}
+	if s.Color != "" {
+		applyStyleColor(obj, v, s.Color, c)
+	}
+	return true
+}
+
+// ResetStyle sets the properties that the object's style set, in a previous list of styles, back to the values
+// of a new object. It returns true if any were reset, the caller should apply the current style again so that
+// properties it still sets are kept.
+func ResetStyle(obj fyne.CanvasObject, prev []Style, c DefyneContext) bool {
+	name := c.Metadata()[obj][StyleProperty]
+	if name == "" || !IsStyleable(obj) {
+		return false
+	}
+	info := Lookup(reflect.TypeOf(obj).String())
+	if info == nil || info.Create == nil {
+		return false
+	}
+
+	for _, s := range prev {
+		if s.Name != name {
+			continue
+		}
+
+		v := reflect.ValueOf(obj).Elem()
+		def := reflect.ValueOf(info.Create(c)).Elem()
+		reset := func(field string) {
+			if f := v.FieldByName(field); f.IsValid() && f.CanSet() {
+				f.Set(def.FieldByName(field))
+			}
+		}
+		if s.Importance != "" {
+			reset("Importance")
+		}
+		if s.Alignment != "" {
+			reset("Alignment")
+		}
+		if s.TextStyle != nil {
+			reset("TextStyle")
+		}
+		if s.TextSize > 0 {
+			reset("TextSize")
+		}
+		if s.Color != "" {
+			for _, field := range styleColorFields {
+				if styleField(v, field, colorType).IsValid() {
+					delete(c.Metadata()[obj], themeColorPrefix+field)
+					reset(field)
+					break
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// IsStyleable returns true if the object has properties that a style can set, and that are generated in code.
+func IsStyleable(obj fyne.CanvasObject) bool {
+	return styledTypes[reflect.TypeOf(obj).String()]
+}
+
+// StyleItems returns the form items to choose the style of an object, or nil if no styles apply to it.
+// The onchanged func is called after a new style is applied.
+func StyleItems(obj fyne.CanvasObject, c DefyneContext, onchanged func()) []*widget.FormItem {
+	styles := projectFor(c).styles
+	if len(styles) == 0 || !IsStyleable(obj) {
+		return nil
+	}
+
+	props := c.Metadata()[obj]
+	if props == nil {
+		props = make(map[string]string)
+		c.Metadata()[obj] = props
+	}
+	names := []string{noStyleLabel}
+	for _, s := range Styles(c) {
+		names = append(names, s.Name)
+	}
+	choose := widget.NewSelect(names, nil)
+	choose.Selected = noStyleLabel
+	if _, ok := styles[props[StyleProperty]]; ok {
+		choose.Selected = props[StyleProperty]
+	}
+	choose.OnChanged = func(name string) {
+		if name == noStyleLabel {
+			delete(props, StyleProperty)
+		} else {
+			props[StyleProperty] = name
+			ApplyStyle(obj, c)
+			obj.Refresh()
+		}
+		onchanged()
+	}
+	return []*widget.FormItem{widget.NewFormItem("Style", choose)}
+}
+
+func applyStyleColor(obj fyne.CanvasObject, v reflect.Value, col string, c DefyneContext) {
+	for _, field := range styleColorFields {
+		f := styleField(v, field, colorType)
+		if !f.IsValid() {
+			continue
+		}
+
+		props := c.Metadata()[obj]
+		key := themeColorPrefix + field
+		if name, ok := themeColorNames[col]; ok {
+			props[key] = string(name)
+			f.Set(reflect.ValueOf(newThemeColor(name, c)))
+		} else {
+			delete(props, key)
+			f.Set(reflect.ValueOf(parseColor(col)))
+		}
+		return
+	}
+}
+
+func buttonAlignment(align string) widget.ButtonAlign {
+	switch align {
+	case "Leading":
+		return widget.ButtonAlignLeading
+	case "Trailing":
+		return widget.ButtonAlignTrailing
+	}
+	return widget.ButtonAlignCenter
+}
+
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func styleField(v reflect.Value, name string, t reflect.Type) reflect.Value {
+	f := v.FieldByName(name)
+	if !f.IsValid() || !f.CanSet() || f.Type() != t {
+		return reflect.Value{}
+	}
+	return f
+}
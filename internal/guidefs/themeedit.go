@@ -0,0 +1,518 @@
+package guidefs
+
+import (
+	"encoding/json"
+	"errors"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	defaultFontLabel = "(Default)"
+
+	// fontThemeType is the name of the generated type that FontThemeGo declares
+	fontThemeType = "fontTheme"
+)
+
+// FontThemeGo is the Go code of the type that generated themes use to show the project fonts they bundle.
+// The fields are named by the font keys of the JSON theme format, and styles are matched as `theme.FromJSON` does.
+const FontThemeGo = `
+// ` + fontThemeType + ` uses the fonts bundled with the app for the styles that a theme sets.
+type ` + fontThemeType + ` struct {
+	fyne.Theme
+	regular, bold, italic, boldItalic, monospace, symbol fyne.Resource
+}
+
+func (t *` + fontThemeType + `) Font(s fyne.TextStyle) fyne.Resource {
+	font := t.regular
+	switch {
+	case s.Monospace:
+		font = t.monospace
+	case s.Bold && s.Italic:
+		font = t.boldItalic
+	case s.Bold:
+		font = t.bold
+	case s.Italic:
+		font = t.italic
+	case s.Symbol:
+		font = t.symbol
+	}
+
+	if font == nil {
+		return t.Theme.Font(s)
+	}
+	return font
+}
+`
+
+var (
+	// themeSizeNames lists the sizes that a theme can set, by the suffix of their `theme.SizeName` constant
+	themeSizeNames = map[string]fyne.ThemeSizeName{
+		"CaptionText":      theme.SizeNameCaptionText,
+		"HeadingText":      theme.SizeNameHeadingText,
+		"InlineIcon":       theme.SizeNameInlineIcon,
+		"InnerPadding":     theme.SizeNameInnerPadding,
+		"InputBorder":      theme.SizeNameInputBorder,
+		"InputRadius":      theme.SizeNameInputRadius,
+		"LineSpacing":      theme.SizeNameLineSpacing,
+		"Padding":          theme.SizeNamePadding,
+		"ScrollBar":        theme.SizeNameScrollBar,
+		"ScrollBarRadius":  theme.SizeNameScrollBarRadius,
+		"ScrollBarSmall":   theme.SizeNameScrollBarSmall,
+		"SelectionRadius":  theme.SizeNameSelectionRadius,
+		"Separator":        theme.SizeNameSeparatorThickness,
+		"SubHeadingText":   theme.SizeNameSubHeadingText,
+		"Text":             theme.SizeNameText,
+		"WindowButtonIcon": theme.SizeNameWindowButtonIcon,
+	}
+
+	// themeFontStyles lists the font keys of the JSON theme format
+	themeFontStyles = []string{"regular", "bold", "italic", "boldItalic", "monospace", "symbol"}
+)
+
+// themeData is the JSON theme format that `theme.FromJSON` reads.
+type themeData struct {
+	Colors      map[string]string  `json:",omitempty"`
+	ColorsLight map[string]string  `json:"Colors-light,omitempty"`
+	ColorsDark  map[string]string  `json:"Colors-dark,omitempty"`
+	Sizes       map[string]float32 `json:",omitempty"`
+	Fonts       map[string]string  `json:",omitempty"`
+	Icons       map[string]string  `json:",omitempty"`
+}
+
+// parseThemeData reads the theme JSON, moving colours for all variants into the light and dark lists for editing.
+func parseThemeData(s string) (*themeData, error) {
+	data := &themeData{}
+	if strings.TrimSpace(s) != "" {
+		if err := json.Unmarshal([]byte(s), data); err != nil {
+			return nil, err
+		}
+	}
+
+	if data.ColorsLight == nil {
+		data.ColorsLight = map[string]string{}
+	}
+	if data.ColorsDark == nil {
+		data.ColorsDark = map[string]string{}
+	}
+	for k, v := range data.Colors {
+		if _, ok := data.ColorsLight[k]; !ok {
+			data.ColorsLight[k] = v
+		}
+		if _, ok := data.ColorsDark[k]; !ok {
+			data.ColorsDark[k] = v
+		}
+	}
+	data.Colors = nil
+	if data.Sizes == nil {
+		data.Sizes = map[string]float32{}
+	}
+	if data.Fonts == nil {
+		data.Fonts = map[string]string{}
+	}
+	return data, nil
+}
+
+// json returns the theme in Fyne JSON format, colours that are the same in both variants are stored once.
+func (t *themeData) json() string {
+	out := &themeData{Colors: map[string]string{}, ColorsLight: map[string]string{}, ColorsDark: map[string]string{},
+		Sizes: t.Sizes, Fonts: t.Fonts, Icons: t.Icons}
+	for k, v := range t.ColorsLight {
+		if t.ColorsDark[k] == v {
+			out.Colors[k] = v
+		} else {
+			out.ColorsLight[k] = v
+		}
+	}
+	for k, v := range t.ColorsDark {
+		if _, ok := out.Colors[k]; !ok {
+			out.ColorsDark[k] = v
+		}
+	}
+
+	data, _ := json.MarshalIndent(out, "", "  ")
+	return string(data)
+}
+
+// ThemeFromJSON parses the theme data of a design, using the theme of the context for anything it does not set.
+// Fonts that are stored relative to the project are loaded from the project directory.
+func ThemeFromJSON(data string, c DefyneContext) (fyne.Theme, error) {
+	return theme.FromJSONWithFallback(resolveThemeFonts(data, func(_, path string) string {
+		if u := projectURI(c, path); u != nil {
+			return u.String()
+		}
+		return path
+	}), fallbackTheme(c))
+}
+
+// ThemeFonts returns the paths of the project fonts that the theme data uses, relative to the project root.
+func ThemeFonts(data string) []string {
+	_, fonts := themeGoJSON(data)
+	used := make(map[string]bool)
+	for _, path := range fonts {
+		used[path] = true
+	}
+
+	paths := make([]string, 0, len(used))
+	for path := range used {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// IsFontFile returns true if the file at the path is a font that a theme can use.
+func IsFontFile(path string) bool {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return false
+	}
+
+	ext := strings.ToLower(path[i:])
+	return ext == ".ttf" || ext == ".otf"
+}
+
+// themeGoJSON returns the theme data for generated code, without the fonts that are stored relative to the project.
+// Those are returned by their font key, as generated code uses the bundled resources for them, see FontThemeGo.
+func themeGoJSON(data string) (string, map[string]string) {
+	fonts := make(map[string]string)
+	out := resolveThemeFonts(data, func(style, path string) string {
+		fonts[style] = path
+		return ""
+	})
+	return out, fonts
+}
+
+// themeFontsGo returns the Go code for a theme that wraps `th` with the bundled fonts, or just `th` if there are none.
+func themeFontsGo(fonts map[string]string) string {
+	if len(fonts) == 0 {
+		return "th"
+	}
+
+	str := &strings.Builder{}
+	str.WriteString("&" + fontThemeType + "{Theme: th")
+	for _, style := range themeFontStyles {
+		if path, ok := fonts[style]; ok {
+			str.WriteString(", " + style + ": " + BundleName(path))
+		}
+	}
+	str.WriteString("}")
+	return str.String()
+}
+
+// resolveThemeFonts returns the theme data with every font that is stored relative to the project
+// replaced by the URI that the resolve function returns for its key and path, or removed if that is empty.
+func resolveThemeFonts(data string, resolve func(string, string) string) string {
+	if strings.TrimSpace(data) == "" {
+		return data
+	}
+	th := &themeData{}
+	if err := json.Unmarshal([]byte(data), th); err != nil {
+		return data // the theme parser will report the error
+	}
+
+	changed := false
+	for k, v := range th.Fonts {
+		if strings.Contains(v, "://") {
+			continue
+		}
+
+		if u := resolve(k, v); u != "" {
+			th.Fonts[k] = u
+		} else {
+			delete(th.Fonts, k)
+		}
+		changed = true
+	}
+	if !changed {
+		return data
+	}
+
+	out, _ := json.MarshalIndent(th, "", "  ")
+	return string(out)
+}
+
+func validateThemeJSON(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	_, err := theme.FromJSON(s)
+	return err
+}
+
+func validateColor(s string) error {
+	if s == "" {
+		return nil
+	}
+	if (len(s) != 7 && len(s) != 9) || s[0] != '#' {
+		return errors.New("colours are written as #rrggbb or #rrggbbaa")
+	}
+	if _, err := strconv.ParseUint(s[1:], 16, 32); err != nil {
+		return errors.New("invalid hexadecimal colour")
+	}
+	return nil
+}
+
+// showThemeEditor opens a dialog to edit theme JSON by each colour, size and font.
+// The updated function is called with the new JSON after every valid change.
+func showThemeEditor(data string, c DefyneContext, updated func(string)) {
+	// TODO get the window passed in somehow
+	w := fyne.CurrentApp().Driver().AllWindows()[0]
+	th, err := parseThemeData(data)
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+
+	light := container.NewThemeOverride(themeSample(), theme.DefaultTheme())
+	dark := container.NewThemeOverride(themeSample(), theme.DefaultTheme())
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	apply := func() {
+		data := th.json()
+		preview, err := ThemeFromJSON(data, c)
+		if err != nil {
+			status.SetText(err.Error())
+			return
+		}
+
+		status.SetText("")
+		light.Theme = NewVariantTheme(preview, theme.VariantLight)
+		light.Refresh()
+		dark.Theme = NewVariantTheme(preview, theme.VariantDark)
+		dark.Refresh()
+		updated(data)
+	}
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Colours", themeColorsEditor(th, w, apply)),
+		container.NewTabItem("Sizes", themeSizesEditor(th, apply)),
+		container.NewTabItem("Fonts", themeFontsEditor(th, c, apply)),
+	)
+	previewPane := container.NewBorder(widget.NewLabelWithStyle("Preview", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		status, nil, nil, container.NewVScroll(container.NewGridWithColumns(2, light, dark)))
+	split := container.NewHSplit(tabs, previewPane)
+	split.Offset = 0.65
+
+	d := dialog.NewCustom("Edit Theme", "Done", split, w)
+	d.Resize(fyne.NewSize(860, 600))
+	d.Show()
+	apply()
+}
+
+// themeSample returns widgets showing the main colours, sizes and fonts of a theme.
+func themeSample() fyne.CanvasObject {
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("Entry")
+	progress := widget.NewProgressBar()
+	progress.SetValue(0.6)
+
+	content := container.NewVBox(
+		widget.NewLabelWithStyle("Heading", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabel("Label text"),
+		entry,
+		widget.NewCheck("Check", nil),
+		widget.NewButton("Button", nil),
+		&widget.Button{Text: "Primary", Importance: widget.HighImportance},
+		progress,
+		widget.NewHyperlink("Hyperlink", nil),
+	)
+	return container.NewStack(newThemeBackground(), container.NewPadded(content))
+}
+
+// themeBackground is a rectangle that fills with the background colour of the theme it is drawn in.
+type themeBackground struct {
+	widget.BaseWidget
+
+	r *canvas.Rectangle
+}
+
+func newThemeBackground() *themeBackground {
+	b := &themeBackground{r: canvas.NewRectangle(theme.Color(theme.ColorNameBackground))}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+func (b *themeBackground) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(b.r)
+}
+
+func (b *themeBackground) Refresh() {
+	b.r.FillColor = b.Theme().Color(theme.ColorNameBackground, fyne.CurrentApp().Settings().ThemeVariant())
+	b.BaseWidget.Refresh()
+}
+
+func themeColorsEditor(th *themeData, w fyne.Window, apply func()) fyne.CanvasObject {
+	rows := container.NewGridWithColumns(3,
+		widget.NewLabelWithStyle("Colour", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Light", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Dark", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for _, label := range themeColorLabels {
+		name := string(themeColorNames[label])
+		rows.Add(widget.NewLabel(label))
+		rows.Add(themeColorInput(th.ColorsLight, name, w, apply))
+		rows.Add(themeColorInput(th.ColorsDark, name, w, apply))
+	}
+	return container.NewVScroll(rows)
+}
+
+// themeColorInput edits a colour of one theme variant, an empty value uses the default colour.
+func themeColorInput(colors map[string]string, name string, w fyne.Window, apply func()) fyne.CanvasObject {
+	input := widget.NewEntry()
+	input.SetPlaceHolder("Default")
+	input.SetText(colors[name])
+	input.Validator = validateColor
+
+	swatch := newColorTapper(parseColor(colors[name]), func(col color.Color) {
+		input.SetText(formatColor(col))
+	}, w)
+	if colors[name] == "" {
+		swatch.setColor(nil)
+	}
+	input.OnChanged = func(s string) {
+		if validateColor(s) != nil {
+			return
+		}
+
+		if s == "" {
+			delete(colors, name)
+			swatch.setColor(nil)
+		} else {
+			colors[name] = s
+			swatch.setColor(parseColor(s))
+		}
+		apply()
+	}
+	return container.NewBorder(nil, nil, swatch, nil, input)
+}
+
+func themeSizesEditor(th *themeData, apply func()) fyne.CanvasObject {
+	labels := make([]string, 0, len(themeSizeNames))
+	for label := range themeSizeNames {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	form := widget.NewForm()
+	for _, label := range labels {
+		name := string(themeSizeNames[label])
+		input := widget.NewEntry()
+		input.SetPlaceHolder(strconv.FormatFloat(float64(theme.DefaultTheme().Size(themeSizeNames[label])), 'f', -1, 32))
+		if size, ok := th.Sizes[name]; ok {
+			input.SetText(strconv.FormatFloat(float64(size), 'f', -1, 32))
+		}
+		input.Validator = func(s string) error {
+			if s == "" {
+				return nil
+			}
+			f, err := strconv.ParseFloat(s, 32)
+			if err != nil || f < 0 {
+				return errors.New("sizes must be a positive number")
+			}
+			return nil
+		}
+		input.OnChanged = func(s string) {
+			if s == "" {
+				delete(th.Sizes, name)
+				apply()
+				return
+			}
+			f, err := strconv.ParseFloat(s, 32)
+			if err != nil || f < 0 {
+				return
+			}
+
+			th.Sizes[name] = float32(f)
+			apply()
+		}
+		form.Append(label, input)
+	}
+	return container.NewVScroll(form)
+}
+
+// themeFontsEditor chooses the fonts of a theme from the project, they are stored relative to the project root
+// so that the design does not depend on where the project is checked out.
+func themeFontsEditor(th *themeData, c DefyneContext, apply func()) fyne.CanvasObject {
+	fonts := projectFonts(c)
+	options := []string{defaultFontLabel}
+	for _, path := range fonts {
+		options = append(options, path)
+	}
+
+	form := widget.NewForm()
+	for _, style := range themeFontStyles {
+		key := style
+		choose := widget.NewSelect(options, nil)
+		choose.SetSelected(defaultFontLabel)
+		for _, path := range fonts {
+			if path == th.Fonts[key] {
+				choose.SetSelected(path)
+			}
+		}
+		choose.OnChanged = func(path string) {
+			delete(th.Fonts, key)
+			if path != defaultFontLabel {
+				th.Fonts[key] = path
+			}
+			apply()
+		}
+		form.Append(key, choose)
+	}
+
+	if len(fonts) == 0 {
+		return container.NewVBox(form, widget.NewLabel("Add .ttf or .otf files to the project to use custom fonts."))
+	}
+	return form
+}
+
+// projectFonts returns the paths, relative to the project root, of the font files found in the project.
+func projectFonts(c DefyneContext) []string {
+	p, ok := c.(ProjectContext)
+	if !ok || p.ProjectRoot() == nil {
+		return nil
+	}
+
+	var fonts []string
+	var search func(dir fyne.URI)
+	search = func(dir fyne.URI) {
+		items, err := storage.List(dir)
+		if err != nil {
+			return
+		}
+		for _, u := range items {
+			if strings.HasPrefix(u.Name(), ".") {
+				continue
+			}
+			if ok, _ := storage.CanList(u); ok {
+				search(u)
+				continue
+			}
+
+			if IsFontFile(u.Name()) {
+				fonts = append(fonts, projectPath(p.ProjectRoot(), u))
+			}
+		}
+	}
+	search(p.ProjectRoot())
+	return fonts
+}
+
+func fallbackTheme(c DefyneContext) fyne.Theme {
+	if th := c.Theme(); th != nil {
+		return th
+	}
+
+	return theme.DefaultTheme()
+}
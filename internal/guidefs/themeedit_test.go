@@ -0,0 +1,58 @@
+package guidefs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseThemeData(t *testing.T) {
+	th, err := parseThemeData(`{"Colors": {"primary": "#ff0000"}, "Colors-dark": {"background": "#000000"},
+		"Sizes": {"padding": 2}, "Fonts": {"regular": "fonts/Inter.ttf"}}`)
+	require.Nil(t, err)
+	assert.Equal(t, "#ff0000", th.ColorsLight["primary"])
+	assert.Equal(t, "#ff0000", th.ColorsDark["primary"])
+	assert.Equal(t, "#000000", th.ColorsDark["background"])
+	assert.Empty(t, th.ColorsLight["background"])
+	assert.Nil(t, th.Colors)
+
+	out := &themeData{}
+	require.Nil(t, json.Unmarshal([]byte(th.json()), out))
+	assert.Equal(t, map[string]string{"primary": "#ff0000"}, out.Colors)
+	assert.Equal(t, map[string]string{"background": "#000000"}, out.ColorsDark)
+	assert.Empty(t, out.ColorsLight)
+	assert.Equal(t, float32(2), out.Sizes["padding"])
+	assert.Equal(t, "fonts/Inter.ttf", out.Fonts["regular"])
+
+	_, err = parseThemeData("{")
+	assert.NotNil(t, err)
+	th, err = parseThemeData("")
+	require.Nil(t, err)
+	assert.NotNil(t, th.Sizes)
+}
+
+func TestValidateColor(t *testing.T) {
+	assert.Nil(t, validateColor(""))
+	assert.Nil(t, validateColor("#12abEF"))
+	assert.Nil(t, validateColor("#12abef80"))
+	assert.NotNil(t, validateColor("12abef"))
+	assert.NotNil(t, validateColor("#12ab"))
+	assert.NotNil(t, validateColor("#12abeg"))
+}
+
+func TestThemeGoJSON(t *testing.T) {
+	data := `{"Fonts": {"bold": "file:///home/me/Bold.ttf", "regular": "fonts/Inter.ttf"}}`
+	code, fonts := themeGoJSON(data)
+	out := &themeData{}
+	require.Nil(t, json.Unmarshal([]byte(code), out))
+	assert.Equal(t, map[string]string{"bold": "file:///home/me/Bold.ttf"}, out.Fonts)
+	assert.Equal(t, map[string]string{"regular": "fonts/Inter.ttf"}, fonts)
+	assert.Equal(t, "&fontTheme{Theme: th, regular: resourceInterTtf}", themeFontsGo(fonts))
+	assert.Equal(t, []string{"fonts/Inter.ttf"}, ThemeFonts(data))
+
+	code, fonts = themeGoJSON(`{"Sizes": {"padding": 2}}`)
+	assert.Equal(t, `{"Sizes": {"padding": 2}}`, code)
+	assert.Equal(t, "th", themeFontsGo(fonts))
+}
@@ -0,0 +1,115 @@
+package guidefs
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// The settings of each toolbar item are stored in the metadata of the toolbar, with keys from ToolbarItemKey.
+const (
+	ToolbarItemAction = "action" // the Go code called when the item is tapped, like the value of an event
+	ToolbarItemName   = "name"   // the variable name of the item
+)
+
+// ToolbarItemKey returns the metadata key of the toolbar that stores a setting of the item at index.
+func ToolbarItemKey(index int, key string) string {
+	return fmt.Sprintf("item%d.%s", index, key)
+}
+
+// toolbarItemMeta holds the settings of one toolbar item while the list of items is rearranged.
+type toolbarItemMeta struct {
+	action, name string
+}
+
+// readToolbarItems returns the settings of each item in a toolbar.
+func readToolbarItems(props map[string]string, count int) []toolbarItemMeta {
+	items := make([]toolbarItemMeta, count)
+	for i := range items {
+		items[i].action = props[ToolbarItemKey(i, ToolbarItemAction)]
+		items[i].name = props[ToolbarItemKey(i, ToolbarItemName)]
+	}
+	return items
+}
+
+// writeToolbarItems replaces the settings of toolbar items in the metadata with those listed.
+func writeToolbarItems(props map[string]string, items []toolbarItemMeta) {
+	for k := range props {
+		if strings.HasPrefix(k, "item") && (strings.HasSuffix(k, "."+ToolbarItemAction) ||
+			strings.HasSuffix(k, "."+ToolbarItemName)) {
+			delete(props, k)
+		}
+	}
+
+	for i, item := range items {
+		if item.action != "" {
+			props[ToolbarItemKey(i, ToolbarItemAction)] = item.action
+		}
+		if item.name != "" {
+			props[ToolbarItemKey(i, ToolbarItemName)] = item.name
+		}
+	}
+}
+
+// toolbarHandlers adds the handler methods that the actions of a toolbar's items call to handlers.
+func toolbarHandlers(bar *widget.Toolbar, props map[string]string, handlers map[string]string) {
+	for i, item := range bar.Items {
+		if _, ok := item.(*widget.ToolbarAction); !ok {
+			continue
+		}
+		if name, ok := handlerName(props[ToolbarItemKey(i, ToolbarItemAction)]); ok {
+			handlers[name] = "()"
+		}
+	}
+}
+
+// toolbarItemGoString returns the code that creates a toolbar item.
+// Named actions are added to defs and referenced from the toolbar.
+func toolbarItemGoString(t widget.ToolbarItem, i int, props map[string]string, defs map[string]string) string {
+	switch t := t.(type) {
+	case *widget.ToolbarSeparator:
+		return "widget.NewToolbarSeparator()"
+	case *widget.ToolbarSpacer:
+		return "widget.NewToolbarSpacer()"
+	case *widget.ToolbarAction:
+		res := "nil"
+		if t.Icon != nil {
+			res = "theme." + IconName(t.Icon) + "()"
+		}
+		action := props[ToolbarItemKey(i, ToolbarItemAction)]
+		if action == "" {
+			action = "func() {}"
+		}
+
+		code := fmt.Sprintf("widget.NewToolbarAction(%s, %s)", res, action)
+		if name := props[ToolbarItemKey(i, ToolbarItemName)]; name != "" {
+			defs[name] = code
+			return fieldRef(name)
+		}
+		return code
+	}
+
+	return "nil"
+}
+
+// ToolbarItemNames returns the variable names of the named items in a toolbar, mapped to their type.
+func ToolbarItemNames(obj fyne.CanvasObject, c DefyneContext) map[string]string {
+	bar, ok := obj.(*widget.Toolbar)
+	if !ok {
+		return nil
+	}
+
+	props := c.Metadata()[obj]
+	names := make(map[string]string)
+	for i, item := range bar.Items {
+		if _, ok := item.(*widget.ToolbarAction); !ok {
+			continue
+		}
+		if name := props[ToolbarItemKey(i, ToolbarItemName)]; name != "" {
+			names[name] = "*widget.ToolbarAction"
+		}
+	}
+	return names
+}
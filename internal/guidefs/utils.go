@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -13,39 +14,27 @@ func escapeLabel(inStr string) (outStr string) {
 	return
 }
 
-func newIconSelectorButton(ic fyne.Resource, fn func(fyne.Resource), showName bool) (iconSel *widget.Button) {
-	items := make([]*fyne.MenuItem, len(IconNames)+1)
+const allIconsLabel = "All"
 
-	items[0] = &fyne.MenuItem{
-		Label: noIconLabel,
-		Icon:  nil,
-		Action: func() {
+func newIconSelectorButton(ic fyne.Resource, fn func(fyne.Resource), showName bool) (iconSel *widget.Button) {
+	choose := func(name string) {
+		if name == "" {
 			iconSel.SetText(noIconLabel)
 			iconSel.SetIcon(nil)
 			fn(nil)
-		},
-	}
-	for i, n := range IconNames {
-		name := n
-		items[i+1] = &fyne.MenuItem{
-			Label: n,
-			Icon:  Icons[n],
-			Action: func() {
-				if showName {
-					iconSel.SetText(name)
-				} else {
-					iconSel.SetText("")
-				}
-				iconSel.SetIcon(Icons[name])
-				fn(Icons[name])
-			},
+			return
+		}
+
+		if showName {
+			iconSel.SetText(name)
+		} else {
+			iconSel.SetText("")
 		}
+		iconSel.SetIcon(Icons[name])
+		fn(Icons[name])
 	}
 	iconSel = widget.NewButton(noIconLabel, func() {
-		d := fyne.CurrentApp().Driver()
-		c := d.CanvasForObject(iconSel)
-		p := d.AbsolutePositionForObject(iconSel).AddXY(0, iconSel.Size().Height)
-		widget.NewPopUpMenu(fyne.NewMenu("", items...), c).ShowAtPosition(p)
+		showIconPicker(iconSel, choose)
 	})
 	if ic != nil {
 		name := IconName(ic)
@@ -65,6 +54,95 @@ func newIconSelectorButton(ic fyne.Resource, fn func(fyne.Resource), showName bo
 	return iconSel
 }
 
+// NewIconSelector returns a button showing the named theme icon that lets the user pick a different one.
+// The chosen func is passed the icon name, or "" if no icon was picked.
+func NewIconSelector(name string, chosen func(string)) *widget.Button {
+	return newIconSelectorButton(Icons[name], func(res fyne.Resource) {
+		if res == nil {
+			chosen("")
+			return
+		}
+		chosen(IconName(res))
+	}, true)
+}
+
+// showIconPicker pops up a searchable list of icons below the given object.
+// The chosen func is passed the icon name, or "" if no icon was picked.
+func showIconPicker(from fyne.CanvasObject, chosen func(string)) {
+	d := fyne.CurrentApp().Driver()
+	c := d.CanvasForObject(from)
+	var pop *widget.PopUp
+
+	matches := filterIcons("", allIconsLabel)
+	list := widget.NewList(
+		func() int {
+			return len(matches)
+		},
+		func() fyne.CanvasObject {
+			category := widget.NewLabelWithStyle("Category", fyne.TextAlignTrailing, fyne.TextStyle{Italic: true})
+			return container.NewBorder(nil, nil, widget.NewIcon(nil), category, widget.NewLabel("Icon name"))
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			row := o.(*fyne.Container)
+			name := matches[id]
+			if name == "" {
+				row.Objects[0].(*widget.Label).SetText(noIconLabel)
+				row.Objects[1].(*widget.Icon).SetResource(nil)
+				row.Objects[2].(*widget.Label).SetText("")
+				return
+			}
+
+			row.Objects[0].(*widget.Label).SetText(name)
+			row.Objects[1].(*widget.Icon).SetResource(Icons[name])
+			row.Objects[2].(*widget.Label).SetText(IconCategory(name))
+		})
+	list.OnSelected = func(id widget.ListItemID) {
+		pop.Hide()
+		chosen(matches[id])
+	}
+
+	search := widget.NewEntry()
+	search.SetPlaceHolder("Search icons")
+	category := widget.NewSelect(append([]string{allIconsLabel}, IconCategories...), nil)
+	category.SetSelected(allIconsLabel)
+	filter := func(string) {
+		matches = filterIcons(search.Text, category.Selected)
+		list.UnselectAll()
+		list.Refresh()
+		list.ScrollToTop()
+	}
+	search.OnChanged = filter
+	category.OnChanged = filter
+
+	top := container.NewBorder(nil, nil, nil, category, search)
+	pop = widget.NewPopUp(container.NewBorder(top, nil, nil, nil, list), c)
+	pop.Resize(fyne.NewSize(360, 420))
+	pop.ShowAtPosition(d.AbsolutePositionForObject(from).AddXY(0, from.Size().Height))
+	c.Focus(search)
+}
+
+// filterIcons returns the icon names in the category that contain the search text.
+// The empty name, meaning no icon, is included first when no filter is applied.
+func filterIcons(search, category string) []string {
+	search = strings.ToLower(search)
+	var names []string
+	if search == "" && category == allIconsLabel {
+		names = append(names, "")
+	}
+
+	for _, n := range IconNames {
+		if category != allIconsLabel && IconCategory(n) != category {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(n), search) {
+			continue
+		}
+
+		names = append(names, n)
+	}
+	return names
+}
+
 func getFormIndex(obj fyne.CanvasObject, list []*widget.FormItem) int {
 	for i, item := range list {
 		if item.Widget == obj {
@@ -80,9 +158,3 @@ func removeFormItem(i int, l []*widget.FormItem) []*widget.FormItem {
 	l[len(l)-1] = nil
 	return l[:len(l)-1]
 }
-
-func removeToolbarItem(i int, l []widget.ToolbarItem) []widget.ToolbarItem {
-	copy(l[i:], l[i+1:])
-	l[len(l)-1] = nil
-	return l[:len(l)-1]
-}
@@ -0,0 +1,231 @@
+package guidefs
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/validation"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	validationRequired = "validation.required"
+	validationMin      = "validation.min"
+	validationMax      = "validation.max"
+	validationRegexp   = "validation.regexp"
+	validationMessage  = "validation.message"
+	validationHandler  = "validation.handler"
+)
+
+// ValidateIdentifier returns an error if the text is not empty and cannot be used as a Go variable or method name.
+func ValidateIdentifier(s string) error {
+	if s == "" || token.IsIdentifier(s) {
+		return nil
+	}
+	return errors.New("not a valid Go name")
+}
+
+// entryValidator returns the validator described by the properties of an entry, or nil if there is none.
+// A custom handler cannot be called from within the builder so it is not included.
+func entryValidator(props map[string]string) fyne.StringValidator {
+	required := props[validationRequired] == "true"
+	minLen, _ := strconv.Atoi(props[validationMin])
+	maxLen, _ := strconv.Atoi(props[validationMax])
+
+	var match fyne.StringValidator
+	if pattern := props[validationRegexp]; pattern != "" {
+		if _, err := regexp.Compile(pattern); err == nil {
+			match = validation.NewRegexp(pattern, validationReason(props))
+		}
+	}
+
+	if !required && minLen <= 0 && maxLen <= 0 && match == nil {
+		return nil
+	}
+	return func(s string) error {
+		if required && s == "" {
+			return errors.New("a value is required")
+		}
+
+		count := len([]rune(s))
+		if minLen > 0 && count < minLen {
+			return fmt.Errorf("must be at least %d characters", minLen)
+		}
+		if maxLen > 0 && count > maxLen {
+			return fmt.Errorf("must be at most %d characters", maxLen)
+		}
+		if match != nil {
+			return match(s)
+		}
+		return nil
+	}
+}
+
+func hasValidation(props map[string]string) bool {
+	return props[validationRequired] == "true" || props[validationMin] != "" || props[validationMax] != "" ||
+		props[validationRegexp] != "" || props[validationHandler] != ""
+}
+
+func validationReason(props map[string]string) string {
+	if msg := props[validationMessage]; msg != "" {
+		return msg
+	}
+
+	return "value does not match the expected format"
+}
+
+// validatorGoString returns the Go code for the validator of an entry, or "" if it has none.
+func validatorGoString(props map[string]string) string {
+	if !hasValidation(props) {
+		return ""
+	}
+
+	pattern := props[validationRegexp]
+	regexpCode := ""
+	if pattern != "" {
+		regexpCode = fmt.Sprintf("validation.NewRegexp(%s, %s)", strconv.Quote(pattern), strconv.Quote(validationReason(props)))
+	}
+	required := props[validationRequired] == "true"
+	minLen, _ := strconv.Atoi(props[validationMin])
+	maxLen, _ := strconv.Atoi(props[validationMax])
+	handler := props[validationHandler]
+	if !required && minLen <= 0 && maxLen <= 0 && handler == "" {
+		return regexpCode
+	}
+
+	str := &strings.Builder{}
+	str.WriteString("func(s string) error {\n")
+	if required {
+		str.WriteString("\tif s == \"\" {\n\t\treturn errors.New(\"a value is required\")\n\t}\n")
+	}
+	if minLen > 0 {
+		str.WriteString(fmt.Sprintf("\tif len([]rune(s)) < %d {\n\t\treturn errors.New(\"must be at least %d characters\")\n\t}\n",
+			minLen, minLen))
+	}
+	if maxLen > 0 {
+		str.WriteString(fmt.Sprintf("\tif len([]rune(s)) > %d {\n\t\treturn errors.New(\"must be at most %d characters\")\n\t}\n",
+			maxLen, maxLen))
+	}
+	if regexpCode != "" {
+		str.WriteString(fmt.Sprintf("\tif err := %s(s); err != nil {\n\t\treturn err\n\t}\n", regexpCode))
+	}
+	if handler != "" {
+		str.WriteString(fmt.Sprintf("\treturn %s(s)\n}", handlerRef(handler)))
+	} else {
+		str.WriteString("\treturn nil\n}")
+	}
+	return str.String()
+}
+
+func validationPackages(props map[string]string) []string {
+	var pkgs []string
+	minLen, _ := strconv.Atoi(props[validationMin])
+	maxLen, _ := strconv.Atoi(props[validationMax])
+	if props[validationRequired] == "true" || minLen > 0 || maxLen > 0 {
+		pkgs = append(pkgs, "errors")
+	}
+	if props[validationRegexp] != "" {
+		pkgs = append(pkgs, "data/validation")
+	}
+	return pkgs
+}
+
+func applyEntryValidation(e *widget.Entry, props map[string]string) {
+	e.Validator = entryValidator(props)
+	_ = e.Validate()
+}
+
+// validationItems returns the form items that edit the validation rules of an entry.
+func validationItems(e *widget.Entry, props map[string]string, onchanged func()) []*widget.FormItem {
+	state := widget.NewLabel("")
+	state.Wrapping = fyne.TextWrapWord
+	update := func() {
+		applyEntryValidation(e, props)
+		switch {
+		case e.Validator == nil:
+			state.SetText("(No rules)")
+		case e.Validate() != nil:
+			state.SetText("Invalid: " + e.Validate().Error())
+		default:
+			state.SetText("Valid")
+		}
+	}
+	setProp := func(key, value string) {
+		if value == "" {
+			delete(props, key)
+		} else {
+			props[key] = value
+		}
+		update()
+		onchanged()
+	}
+
+	required := widget.NewCheck("", func(on bool) {
+		value := ""
+		if on {
+			value = "true"
+		}
+		setProp(validationRequired, value)
+	})
+	required.Checked = props[validationRequired] == "true"
+
+	newLength := func(key string) *widget.Entry {
+		length := widget.NewEntry()
+		length.SetPlaceHolder("(None)")
+		length.SetText(props[key])
+		length.Validator = validation.NewRegexp("^[0-9]*$", "Must be a number")
+		length.OnChanged = func(s string) {
+			if length.Validate() != nil {
+				return
+			}
+			setProp(key, s)
+		}
+		return length
+	}
+
+	pattern := widget.NewEntry()
+	pattern.SetText(props[validationRegexp])
+	pattern.Validator = func(s string) error {
+		_, err := regexp.Compile(s)
+		return err
+	}
+	pattern.OnChanged = func(s string) {
+		if pattern.Validate() != nil {
+			return
+		}
+		setProp(validationRegexp, s)
+	}
+	message := widget.NewEntry()
+	message.SetText(props[validationMessage])
+	message.OnChanged = func(s string) {
+		setProp(validationMessage, s)
+	}
+
+	handler := widget.NewEntry()
+	handler.SetPlaceHolder("Method name")
+	handler.SetText(props[validationHandler])
+	handler.Validator = ValidateIdentifier
+	handler.OnChanged = func(s string) {
+		if handler.Validate() != nil {
+			return
+		}
+		setProp(validationHandler, s)
+	}
+
+	update()
+	return []*widget.FormItem{
+		widget.NewFormItem("", widget.NewLabelWithStyle("Validation", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})),
+		widget.NewFormItem("Required", required),
+		widget.NewFormItem("Min Length", newLength(validationMin)),
+		widget.NewFormItem("Max Length", newLength(validationMax)),
+		widget.NewFormItem("Pattern", pattern),
+		widget.NewFormItem("Message", message),
+		{Text: "Handler", Widget: handler, HintText: "Method on the GUI type, func(string) error"},
+		widget.NewFormItem("State", state),
+	}
+}
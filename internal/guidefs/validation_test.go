@@ -0,0 +1,16 @@
+package guidefs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIdentifier(t *testing.T) {
+	assert.Nil(t, ValidateIdentifier(""))
+	assert.Nil(t, ValidateIdentifier("submit"))
+	assert.Nil(t, ValidateIdentifier("_name2"))
+	assert.NotNil(t, ValidateIdentifier("2name"))
+	assert.NotNil(t, ValidateIdentifier("my-name"))
+	assert.NotNil(t, ValidateIdentifier("func"))
+}
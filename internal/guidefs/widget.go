@@ -26,6 +26,13 @@ type DefyneContext interface {
 	Theme() fyne.Theme
 }
 
+// ProjectContext is an optional extension of DefyneContext for builders that know which project they are editing.
+// It is used to look up resources, such as images, that are stored in the project.
+type ProjectContext interface {
+	DefyneContext
+	ProjectRoot() fyne.URI
+}
+
 var (
 	// WidgetNames is an array with the list of names of all the Widgets
 	WidgetNames []string
@@ -54,6 +61,7 @@ type WidgetInfo struct {
 	Edit     func(fyne.CanvasObject, DefyneContext, func([]*widget.FormItem), func()) []*widget.FormItem
 	Gostring func(fyne.CanvasObject, DefyneContext, map[string]string) string
 	Packages func(fyne.CanvasObject, DefyneContext) []string
+	Restore  func(fyne.CanvasObject, DefyneContext)
 }
 
 // IsContainer indicates whether a widget children or not
@@ -308,11 +316,12 @@ func initButtonWidget() WidgetInfo {
 		Create: func(DefyneContext) fyne.CanvasObject {
 			return widget.NewButton("Button", func() {})
 		},
-		Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+		Edit: func(obj fyne.CanvasObject, ctx DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 			b := obj.(*widget.Button)
 			entry := widget.NewEntry()
 			entry.SetText(b.Text)
 			entry.OnChanged = func(text string) {
+				unlinkString(obj, ctx, "Text")
 				b.SetText(text)
 				onchanged()
 			}
@@ -383,20 +392,20 @@ func initButtonWidget() WidgetInfo {
 			}
 			if b.Icon == nil {
 				if b.Importance == widget.MediumImportance && b.Alignment == widget.ButtonAlignCenter {
-					return widgetRef(props, defs, fmt.Sprintf("widget.NewButton(\"%s\", %s)", escapeLabel(b.Text), action))
+					return widgetRef(props, defs, fmt.Sprintf("widget.NewButton(%s, %s)", textGoString(c, props, "Text", b.Text), action))
 				}
 
-				return widgetRef(props, defs, fmt.Sprintf("&widget.Button{Text: \"%s\", Importance: %d, Alignment: %d, OnTapped: %s}",
-					escapeLabel(b.Text), b.Importance, b.Alignment, action))
+				return widgetRef(props, defs, fmt.Sprintf("&widget.Button{Text: %s, Importance: %d, Alignment: %d, OnTapped: %s}",
+					textGoString(c, props, "Text", b.Text), b.Importance, b.Alignment, action))
 			}
 
 			icon := "theme." + IconName(b.Icon) + "()"
 			if b.Importance == widget.MediumImportance && b.Alignment == widget.ButtonAlignCenter {
-				return widgetRef(props, defs, fmt.Sprintf("widget.NewButtonWithIcon(\"%s\", %s, %s)", escapeLabel(b.Text), icon, action))
+				return widgetRef(props, defs, fmt.Sprintf("widget.NewButtonWithIcon(%s, %s, %s)", textGoString(c, props, "Text", b.Text), icon, action))
 			}
 
-			return widgetRef(props, defs, fmt.Sprintf("&widget.Button{Text: \"%s\", Importance: %d, Icon: %s, Alignment: %d, OnTapped: %s}",
-				escapeLabel(b.Text), b.Importance, icon, b.Alignment, action))
+			return widgetRef(props, defs, fmt.Sprintf("&widget.Button{Text: %s, Importance: %d, Icon: %s, Alignment: %d, OnTapped: %s}",
+				textGoString(c, props, "Text", b.Text), b.Importance, icon, b.Alignment, action))
 		},
 		Packages: func(obj fyne.CanvasObject, _ DefyneContext) []string {
 			b := obj.(*widget.Button)
@@ -412,6 +421,13 @@ func initButtonWidget() WidgetInfo {
 func initCardWidget() WidgetInfo {
 	return WidgetInfo{
 		Name: "Card",
+		Children: func(o fyne.CanvasObject) []fyne.CanvasObject {
+			return []fyne.CanvasObject{o.(*widget.Card).Content}
+		},
+		AddChild: func(parent, o fyne.CanvasObject) {
+			c := parent.(*widget.Card)
+			c.SetContent(o)
+		},
 		Create: func(DefyneContext) fyne.CanvasObject {
 			return widget.NewCard("Title", "Subtitle", widget.NewLabel("Content here"))
 		},
@@ -435,8 +451,11 @@ func initCardWidget() WidgetInfo {
 		},
 		Gostring: func(obj fyne.CanvasObject, ctx DefyneContext, defs map[string]string) string {
 			c := obj.(*widget.Card)
-			return widgetRef(ctx.Metadata()[obj], defs, fmt.Sprintf("widget.NewCard(\"%s\", \"%s\", widget.NewLabel(\"Content here\"))",
-				escapeLabel(c.Title), escapeLabel(c.Subtitle)))
+			str := &strings.Builder{}
+			str.WriteString(fmt.Sprintf("widget.NewCard(\"%s\", \"%s\", ", escapeLabel(c.Title), escapeLabel(c.Subtitle)))
+			writeGoStringOrNil(str, ctx, defs, c.Content)
+			str.WriteString(")")
+			return widgetRef(ctx.Metadata()[obj], defs, str.String())
 		},
 	}
 }
@@ -447,11 +466,12 @@ func initCheckWidget() WidgetInfo {
 		Create: func(DefyneContext) fyne.CanvasObject {
 			return widget.NewCheck("Tick it or don't", func(b bool) {})
 		},
-		Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+		Edit: func(obj fyne.CanvasObject, ctx DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 			c := obj.(*widget.Check)
 			title := widget.NewEntry()
 			title.SetText(c.Text)
 			title.OnChanged = func(text string) {
+				unlinkString(obj, ctx, "Text")
 				c.Text = text
 				c.Refresh()
 				onchanged()
@@ -471,7 +491,7 @@ func initCheckWidget() WidgetInfo {
 		Gostring: func(obj fyne.CanvasObject, ctx DefyneContext, defs map[string]string) string {
 			c := obj.(*widget.Check)
 			return widgetRef(ctx.Metadata()[obj], defs,
-				fmt.Sprintf("widget.NewCheck(\"%s\", func(b bool) {})", escapeLabel(c.Text)))
+				fmt.Sprintf("widget.NewCheck(%s, func(b bool) {})", textGoString(ctx, ctx.Metadata()[obj], "Text", c.Text)))
 		},
 	}
 }
@@ -482,15 +502,29 @@ func initDateEntryWidget() WidgetInfo {
 		Create: func(DefyneContext) fyne.CanvasObject {
 			return widget.NewDateEntry()
 		},
-		Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), _ func()) []*widget.FormItem {
-			_ = obj.(*widget.DateEntry)
+		Edit: func(obj fyne.CanvasObject, c DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+			d := obj.(*widget.DateEntry)
 
-			return []*widget.FormItem{}
+			return validationItems(&d.Entry, c.Metadata()[obj], onchanged)
 		},
 		Gostring: func(obj fyne.CanvasObject, c DefyneContext, defs map[string]string) string {
-			_ = obj.(*widget.DateEntry)
+			props := c.Metadata()[obj]
+			validator := validatorGoString(props)
+			if validator == "" {
+				return widgetRef(props, defs, "widget.NewDateEntry()")
+			}
 
-			return widgetRef(c.Metadata()[obj], defs, "widget.NewDateEntry()")
+			return widgetRef(props, defs, fmt.Sprintf(`func() *widget.DateEntry {
+		d := widget.NewDateEntry()
+		d.Validator = %s
+		return d
+	}()`, validator))
+		},
+		Packages: func(obj fyne.CanvasObject, c DefyneContext) []string {
+			return append([]string{"widget"}, validationPackages(c.Metadata()[obj])...)
+		},
+		Restore: func(obj fyne.CanvasObject, c DefyneContext) {
+			applyEntryValidation(&obj.(*widget.DateEntry).Entry, c.Metadata()[obj])
 		},
 	}
 }
@@ -503,29 +537,43 @@ func initEntryWidget() WidgetInfo {
 			e.SetPlaceHolder("Entry")
 			return e
 		},
-		Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+		Edit: func(obj fyne.CanvasObject, c DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 			l := obj.(*widget.Entry)
 			entry1 := widget.NewEntry()
 			entry1.SetText(l.Text)
 			entry1.OnChanged = func(text string) {
+				unlinkString(obj, c, "Text")
 				l.SetText(text)
 				onchanged()
 			}
 			entry2 := widget.NewEntry()
 			entry2.SetText(l.PlaceHolder)
 			entry2.OnChanged = func(text string) {
+				unlinkString(obj, c, "PlaceHolder")
 				l.SetPlaceHolder(text)
 				onchanged()
 			}
-			return []*widget.FormItem{
+			items := []*widget.FormItem{
 				widget.NewFormItem("Text", entry1),
 				widget.NewFormItem("PlaceHolder", entry2)}
+			return append(items, validationItems(l, c.Metadata()[obj], onchanged)...)
 		},
 		Gostring: func(obj fyne.CanvasObject, c DefyneContext, defs map[string]string) string {
+			props := c.Metadata()[obj]
 			l := obj.(*widget.Entry)
-			return widgetRef(c.Metadata()[obj], defs,
-				fmt.Sprintf("&widget.Entry{Text: \"%s\", PlaceHolder: \"%s\", MultiLine: %t, Password: %t}",
-					escapeLabel(l.Text), escapeLabel(l.PlaceHolder), l.MultiLine, l.Password))
+			validator := ""
+			if code := validatorGoString(props); code != "" {
+				validator = ", Validator: " + code
+			}
+			return widgetRef(props, defs,
+				fmt.Sprintf("&widget.Entry{Text: %s, PlaceHolder: %s, MultiLine: %t, Password: %t%s}",
+					textGoString(c, props, "Text", l.Text), textGoString(c, props, "PlaceHolder", l.PlaceHolder), l.MultiLine, l.Password, validator))
+		},
+		Packages: func(obj fyne.CanvasObject, c DefyneContext) []string {
+			return append([]string{"widget"}, validationPackages(c.Metadata()[obj])...)
+		},
+		Restore: func(obj fyne.CanvasObject, c DefyneContext) {
+			applyEntryValidation(obj.(*widget.Entry), c.Metadata()[obj])
 		},
 	}
 }
@@ -675,11 +723,12 @@ func initHyperlinkWidget() WidgetInfo {
 			fyneURL, _ := url.Parse("https://fyne.io")
 			return widget.NewHyperlink("Link Text", fyneURL)
 		},
-		Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+		Edit: func(obj fyne.CanvasObject, c DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 			link := obj.(*widget.Hyperlink)
 			title := widget.NewEntry()
 			title.SetText(link.Text)
 			title.OnChanged = func(text string) {
+				unlinkString(obj, c, "Text")
 				link.SetText(text)
 				onchanged()
 			}
@@ -696,7 +745,8 @@ func initHyperlinkWidget() WidgetInfo {
 		},
 		Gostring: func(obj fyne.CanvasObject, c DefyneContext, defs map[string]string) string {
 			link := obj.(*widget.Hyperlink)
-			return widgetRef(c.Metadata()[obj], defs, fmt.Sprintf(`widget.NewHyperlink("%s", %#v)`, escapeLabel(link.Text), link.URL))
+			props := c.Metadata()[obj]
+			return widgetRef(props, defs, fmt.Sprintf(`widget.NewHyperlink(%s, %#v)`, textGoString(c, props, "Text", link.Text), link.URL))
 		},
 		Packages: func(_ fyne.CanvasObject, _ DefyneContext) []string {
 			return []string{"net/url"}
@@ -736,11 +786,12 @@ func initLabelWidget() WidgetInfo {
 		Create: func(DefyneContext) fyne.CanvasObject {
 			return widget.NewLabel("Label")
 		},
-		Edit: func(obj fyne.CanvasObject, _ DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+		Edit: func(obj fyne.CanvasObject, c DefyneContext, _ func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
 			l := obj.(*widget.Label)
 			entry := widget.NewEntry()
 			entry.SetText(l.Text)
 			entry.OnChanged = func(text string) {
+				unlinkString(obj, c, "Text")
 				l.SetText(text)
 				onchanged()
 			}
@@ -821,22 +872,25 @@ func initLabelWidget() WidgetInfo {
 		Gostring: func(obj fyne.CanvasObject, c DefyneContext, defs map[string]string) string {
 			props := c.Metadata()[obj]
 			l := obj.(*widget.Label)
-			if l.Alignment != fyne.TextAlignLeading || l.Wrapping != fyne.TextWrapOff {
+			if l.Alignment != fyne.TextAlignLeading || l.Wrapping != fyne.TextWrapOff || l.Importance != widget.MediumImportance {
 				style := ""
 				if l.TextStyle.Bold || l.TextStyle.Italic || l.TextStyle.Monospace {
 					style = fmt.Sprintf(", TextStyle: %#v", l.TextStyle)
 				}
+				if l.Importance != widget.MediumImportance {
+					style += fmt.Sprintf(", Importance: %d", l.Importance)
+				}
 
 				return widgetRef(props, defs,
-					fmt.Sprintf("&widget.Label{Text: \"%s\"%s, Alignment: %d, Wrapping: %d}", escapeLabel(l.Text), style, l.Alignment, l.Wrapping))
+					fmt.Sprintf("&widget.Label{Text: %s%s, Alignment: %d, Wrapping: %d}", textGoString(c, props, "Text", l.Text), style, l.Alignment, l.Wrapping))
 			}
 
 			if l.TextStyle.Bold || l.TextStyle.Italic || l.TextStyle.Monospace {
 				return widgetRef(props, defs,
-					fmt.Sprintf("widget.NewLabelWithStyle(\"%s\", %d, %#v)", escapeLabel(l.Text), l.Alignment, l.TextStyle))
+					fmt.Sprintf("widget.NewLabelWithStyle(%s, %d, %#v)", textGoString(c, props, "Text", l.Text), l.Alignment, l.TextStyle))
 			}
 			return widgetRef(props, defs,
-				fmt.Sprintf("widget.NewLabel(\"%s\")", escapeLabel(l.Text)))
+				fmt.Sprintf("widget.NewLabel(%s)", textGoString(c, props, "Text", l.Text)))
 		},
 	}
 }
@@ -1092,106 +1146,129 @@ func initToolbarWidget() WidgetInfo {
 				widget.NewToolbarAction(Icons["HelpIcon"], func() { fmt.Println("Clicked on HelpIcon") }),
 			)
 		},
-		Edit: func(obj fyne.CanvasObject, _ DefyneContext, refresh func([]*widget.FormItem), _ func()) []*widget.FormItem {
-			items := []*widget.FormItem{}
-			toolItems := obj.(*widget.Toolbar).Items
-
-			add := widget.NewButtonWithIcon("Add...", theme.ContentAddIcon(), nil)
-			addLine := widget.NewFormItem("", add)
+		Edit: func(obj fyne.CanvasObject, c DefyneContext, refresh func([]*widget.FormItem), onchanged func()) []*widget.FormItem {
+			bar := obj.(*widget.Toolbar)
+			props := c.Metadata()[obj]
 			options := []string{"Action", "Separator", "Spacer"}
 
-			removeItem := func(i int) {
-				toolItems = removeToolbarItem(i, toolItems)
-				obj.(*widget.Toolbar).Items = toolItems
-				obj.Refresh()
-				items = removeFormItem(i, items)
-				refresh(append(items, addLine))
+			var build func() []*widget.FormItem
+			update := func(items []widget.ToolbarItem, meta []toolbarItemMeta) {
+				bar.Items = items
+				writeToolbarItems(props, meta)
+				bar.Refresh()
+				refresh(build())
+				onchanged()
 			}
 
 			newToolEdit := func(id int, o widget.ToolbarItem) *widget.FormItem {
-				chosen := ""
-				holder := container.NewStack()
-				var wid fyne.CanvasObject
-
-				switch t := o.(type) {
+				chosen := options[0]
+				switch o.(type) {
 				case *widget.ToolbarSeparator:
 					chosen = options[1]
 				case *widget.ToolbarSpacer:
 					chosen = options[2]
-				case *widget.ToolbarAction:
-					chosen = options[0]
-					wid = newIconSelectorButton(t.Icon, t.SetIcon, false)
-					holder.Objects = []fyne.CanvasObject{wid}
 				}
 
-				chooser := widget.NewSelect(options, func(s string) {
+				chooser := widget.NewSelect(options, nil)
+				chooser.Selected = chosen
+				chooser.OnChanged = func(s string) {
+					items := append([]widget.ToolbarItem{}, bar.Items...)
+					meta := readToolbarItems(props, len(items))
 					switch s {
 					case "Separator":
-						toolItems[id] = widget.NewToolbarSeparator()
-						items[id].Text = "Separator"
-						holder.Objects = nil
+						items[id] = widget.NewToolbarSeparator()
 					case "Spacer":
-						toolItems[id] = widget.NewToolbarSpacer()
-						items[id].Text = "Spacer"
-						holder.Objects = nil
+						items[id] = widget.NewToolbarSpacer()
 					default:
-						act := widget.NewToolbarAction(theme.QuestionIcon(), nil)
-						toolItems[id] = act
-						items[id].Text = "Action"
+						items[id] = widget.NewToolbarAction(theme.QuestionIcon(), nil)
+					}
+					meta[id] = toolbarItemMeta{}
+					update(items, meta)
+				}
 
-						holder.Objects = []fyne.CanvasObject{newIconSelectorButton(act.Icon, act.SetIcon, false)}
+				move := func(delta int) {
+					to := id + delta
+					if to < 0 || to >= len(bar.Items) {
+						return
 					}
 
-					obj.Refresh()
-					refresh(append(items, addLine))
+					items := append([]widget.ToolbarItem{}, bar.Items...)
+					meta := readToolbarItems(props, len(items))
+					items[id], items[to] = items[to], items[id]
+					meta[id], meta[to] = meta[to], meta[id]
+					update(items, meta)
+				}
+				up := widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() {
+					move(-1)
+				})
+				down := widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() {
+					move(1)
 				})
-				chooser.Selected = chosen
-
-				var row *fyne.Container
 				remove := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
-					index := getFormIndex(row, items)
-					removeItem(index)
+					items := append([]widget.ToolbarItem{}, bar.Items...)
+					meta := readToolbarItems(props, len(items))
+					update(append(items[:id], items[id+1:]...), append(meta[:id], meta[id+1:]...))
 				})
-				row = container.NewBorder(nil, nil, chooser, remove, holder)
-				return widget.NewFormItem(chosen, row)
-			}
-
-			add.OnTapped = func() {
-				newID := len(toolItems)
-				newAction := widget.NewToolbarAction(theme.QuestionIcon(), nil)
-				toolItems = append(toolItems, newAction)
+				if id == 0 {
+					up.Disable()
+				}
+				if id == len(bar.Items)-1 {
+					down.Disable()
+				}
+				row := container.NewBorder(nil, nil, chooser, container.NewHBox(up, down, remove))
 
-				obj.(*widget.Toolbar).Items = toolItems
-				obj.Refresh()
-				items = append(items, newToolEdit(newID, newAction))
+				act, ok := o.(*widget.ToolbarAction)
+				if !ok {
+					return widget.NewFormItem(chosen, row)
+				}
 
-				obj.Refresh()
-				refresh(append(items, addLine))
+				row.Add(newIconSelectorButton(act.Icon, func(res fyne.Resource) {
+					act.SetIcon(res)
+					onchanged()
+				}, false))
+				key := ToolbarItemKey(id, ToolbarItemName)
+				name := widget.NewEntry()
+				name.SetPlaceHolder("variable name")
+				name.SetText(props[key])
+				name.Validator = ValidateIdentifier
+				name.OnChanged = func(s string) {
+					if name.Validate() != nil {
+						return
+					}
+					if s == "" {
+						delete(props, key)
+					} else {
+						props[key] = s
+					}
+					onchanged()
+				}
+				action := eventEditor(props, ToolbarItemKey(id, ToolbarItemAction), "func()", onchanged)
+				return widget.NewFormItem(chosen, container.NewVBox(row, name, action))
 			}
 
-			for i, o := range toolItems {
-				items = append(items, newToolEdit(i, o))
+			add := widget.NewButtonWithIcon("Add...", theme.ContentAddIcon(), func() {
+				items := append(bar.Items, widget.NewToolbarAction(theme.QuestionIcon(), nil))
+				update(items, readToolbarItems(props, len(items)))
+			})
+			build = func() []*widget.FormItem {
+				items := make([]*widget.FormItem, len(bar.Items))
+				for i, o := range bar.Items {
+					items[i] = newToolEdit(i, o)
+				}
+				return append(items, widget.NewFormItem("", add))
 			}
 
-			return append(items, addLine)
+			return build()
 		},
 		Gostring: func(obj fyne.CanvasObject, c DefyneContext, defs map[string]string) string {
+			props := c.Metadata()[obj]
 			str := &strings.Builder{}
 			str.WriteString("widget.NewToolbar(\n")
-			for _, i := range obj.(*widget.Toolbar).Items {
-				switch t := i.(type) {
-				case *widget.ToolbarSeparator:
-					str.WriteString("\t\t\t\twidget.NewToolbarSeparator(),\n")
-				case *widget.ToolbarSpacer:
-					str.WriteString("\t\t\t\twidget.NewToolbarSpacer(),\n")
-				case *widget.ToolbarAction:
-					res := "theme." + IconName(t.Icon) + "()"
-					str.WriteString(fmt.Sprintf("\t\t\t\twidget.NewToolbarAction(%s, func() {}),\n", res))
-					// TODO action handler
-				}
+			for i, item := range obj.(*widget.Toolbar).Items {
+				str.WriteString("\t\t\t\t" + toolbarItemGoString(item, i, props, defs) + ",\n")
 			}
 			str.WriteString(")")
-			return widgetRef(c.Metadata()[obj], defs, str.String())
+			return widgetRef(props, defs, str.String())
 		},
 	}
 }
@@ -1222,7 +1299,7 @@ func extractNames(in map[string]WidgetInfo) []string {
 func widgetRef(props map[string]string, defs map[string]string, code string) string {
 	if name, ok := props["name"]; ok && name != "" {
 		defs[name] = code
-		return "g." + name
+		return fieldRef(name)
 	}
 
 	return code
@@ -1256,6 +1333,17 @@ func Lookup(clazz string) *WidgetInfo {
 	return nil
 }
 
+// Restore applies any properties that are stored in the metadata, rather than the fields, of a decoded object
+func Restore(obj fyne.CanvasObject, c DefyneContext) {
+	restoreThemeColors(obj, c)
+	ApplyStyle(obj, c)
+	ApplyStrings(obj, c)
+
+	if info := Lookup(reflect.TypeOf(obj).String()); info != nil && info.Restore != nil {
+		info.Restore(obj, c)
+	}
+}
+
 type widgetNames []string
 
 func (w widgetNames) Len() int {
@@ -4,12 +4,15 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/storage"
 )
 
@@ -23,10 +26,46 @@ func (d *defyne) setProject(u fyne.URI) {
 	mainSplit.Offset = 0.2
 
 	d.win.SetMainMenu(d.makeMenu())
+	d.win.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyS, Modifier: fyne.KeyModifierShortcutDefault},
+		func(fyne.Shortcut) { d.menuActionSave() })
+	d.win.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyS,
+		Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift},
+		func(fyne.Shortcut) { d.menuActionSaveAll() })
+	d.win.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierShortcutDefault},
+		func(fyne.Shortcut) { d.menuActionUndo() })
+	d.win.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyZ,
+		Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift},
+		func(fyne.Shortcut) { d.menuActionRedo() })
+	d.win.Canvas().AddShortcut(&fyne.ShortcutCut{}, func(fyne.Shortcut) { d.menuActionCut() })
+	d.win.Canvas().AddShortcut(&fyne.ShortcutCopy{}, func(fyne.Shortcut) { d.menuActionCopy() })
+	d.win.Canvas().AddShortcut(&fyne.ShortcutPaste{}, func(fyne.Shortcut) { d.menuActionPaste() })
+	d.win.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyD, Modifier: fyne.KeyModifierShortcutDefault},
+		func(fyne.Shortcut) { d.menuActionDuplicate() })
 	d.win.SetContent(container.NewBorder(d.makeToolbar(), nil, nil, nil, mainSplit))
+	d.win.SetCloseIntercept(func() {
+		if !d.hasUnsaved() {
+			d.win.Close()
+			return
+		}
+
+		dialog.ShowConfirm("Files are unsaved", "Are you sure you wish to quit?",
+			func(ok bool) {
+				if ok {
+					d.win.Close()
+				}
+			}, d.win)
+	})
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	a := app.NewWithID("io.fyne.defyne")
 	a.SetIcon(resourceIconPng)
 	w := a.NewWindow("Defyne")
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/fyne-io/defyne/internal/guibuilder"
+)
+
+// Declare conformity with editor interface
+var _ editor = (*menuEditor)(nil)
+
+type menuEditor struct {
+	uri       fyne.URI
+	builder   *guibuilder.MenuBuilder
+	win       fyne.Window
+	onChanged func()
+}
+
+func newMenuEditor(u fyne.URI, win fyne.Window) editor {
+	builder := guibuilder.NewMenuBuilder(u, win)
+	m := &menuEditor{uri: u, builder: builder, win: win}
+	builder.OnChanged = m.notifyChanged
+	return m
+}
+
+func (m *menuEditor) changed() bool {
+	return m.builder.Changed()
+}
+
+func (m *menuEditor) content() fyne.CanvasObject {
+	return m.builder.MakeUI()
+}
+
+func (m *menuEditor) close() {
+}
+
+func (m *menuEditor) notifyChanged() {
+	if m.onChanged != nil {
+		m.onChanged()
+	}
+}
+
+func (m *menuEditor) run() {
+	m.builder.Run()
+}
+
+func (m *menuEditor) save() {
+	err := m.builder.Save()
+	var partial *guibuilder.CodeNotGeneratedError
+	if errors.As(err, &partial) {
+		dialog.ShowInformation("Menu saved", partial.Error(), m.win)
+	} else if err != nil {
+		dialog.ShowError(err, m.win)
+	}
+
+	// the menu design may have been saved even if there was a problem
+	m.notifyChanged()
+}
+
+func (m *menuEditor) setOnChanged(fn func()) {
+	m.onChanged = fn
+}
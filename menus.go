@@ -80,17 +80,77 @@ func (d *defyne) menuActionRunProject() {
 }
 
 func (d *defyne) menuActionRun() {
-	if ed, ok := d.openEditors[d.fileTabs.Selected()]; ok {
+	if ed, ok := d.selectedEditor(); ok {
 		ed.run()
 	}
 }
 
+func (d *defyne) menuActionRunCompiled() {
+	if ed, ok := d.selectedEditor(); ok {
+		if c, ok := ed.editor.(compiler); ok {
+			c.runCompiled()
+		}
+	}
+}
+
 func (d *defyne) menuActionSave() {
-	if ed, ok := d.openEditors[d.fileTabs.Selected()]; ok {
+	if ed, ok := d.selectedEditor(); ok {
 		ed.save()
 	}
 }
 
+func (d *defyne) menuActionSaveAll() {
+	for _, ed := range d.openEditors {
+		if ed.changed() {
+			ed.save()
+		}
+	}
+}
+
+func (d *defyne) menuActionUndo() {
+	if ed, ok := d.selectedEditor(); ok {
+		if u, ok := ed.editor.(undoer); ok {
+			u.undo()
+		}
+	}
+}
+
+func (d *defyne) menuActionRedo() {
+	if ed, ok := d.selectedEditor(); ok {
+		if u, ok := ed.editor.(undoer); ok {
+			u.redo()
+		}
+	}
+}
+
+func (d *defyne) menuActionClipboard(fn func(clipboarder)) {
+	if ed, ok := d.selectedEditor(); ok {
+		if c, ok := ed.editor.(clipboarder); ok {
+			fn(c)
+		}
+	}
+}
+
+func (d *defyne) menuActionCut() {
+	d.menuActionClipboard(clipboarder.cut)
+}
+
+func (d *defyne) menuActionCopy() {
+	d.menuActionClipboard(clipboarder.copy)
+}
+
+func (d *defyne) menuActionPaste() {
+	d.menuActionClipboard(clipboarder.paste)
+}
+
+func (d *defyne) menuActionDuplicate() {
+	d.menuActionClipboard(clipboarder.duplicate)
+}
+
+func (d *defyne) menuActionMoveToOtherSide() {
+	d.moveToOtherSide()
+}
+
 func (d *defyne) menuActionFullScreenToggle() {
 	d.win.SetFullScreen(!d.win.FullScreen())
 }
@@ -129,9 +189,23 @@ func (d *defyne) makeMenu() *fyne.MainMenu {
 			fyne.NewMenuItem("New File...", d.menuActionNew),
 			fyne.NewMenuItemSeparator(),
 			fyne.NewMenuItem("Save", d.menuActionSave),
+			fyne.NewMenuItem("Save All", d.menuActionSaveAll),
 			fyne.NewMenuItemSeparator(),
 			fyne.NewMenuItem("Run", d.menuActionRun),
+			fyne.NewMenuItem("Run Compiled", d.menuActionRunCompiled),
 			fyne.NewMenuItem("Run Project", d.menuActionRunProject),
+		),
+		fyne.NewMenu("Edit",
+			fyne.NewMenuItem("Undo", d.menuActionUndo),
+			fyne.NewMenuItem("Redo", d.menuActionRedo),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Cut", d.menuActionCut),
+			fyne.NewMenuItem("Copy", d.menuActionCopy),
+			fyne.NewMenuItem("Paste", d.menuActionPaste),
+			fyne.NewMenuItem("Duplicate", d.menuActionDuplicate),
+		),
+		fyne.NewMenu("View",
+			fyne.NewMenuItem("Move Tab to Other Side", d.menuActionMoveToOtherSide),
 		))
 	if runtime.GOOS != "darwin" {
 		menu.Items = append(menu.Items,
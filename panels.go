@@ -21,15 +21,16 @@ func (d *defyne) makeEditorPanel() fyne.CanvasObject {
 		container.NewTabItemWithIcon("Welcome", theme.HomeIcon(),
 			container.NewCenter(welcome)))
 
-	d.fileTabs.CloseIntercept = func(t *container.TabItem) {
+	d.sideTabs = container.NewDocTabs()
+	d.activeTabs = d.fileTabs
+
+	closeIntercept := func(t *container.TabItem) {
 		ed, ok := d.openEditors[t]
 		if !ok { // welcome tab
 			return
 		}
 		if !ed.changed() {
-			ed.close()
-			d.fileTabs.Remove(t)
-			delete(d.openEditors, t)
+			d.closeTab(t)
 			return
 		}
 		dialog.ShowConfirm("File is unsaved", "Are you sure you wish to close?",
@@ -38,13 +39,19 @@ func (d *defyne) makeEditorPanel() fyne.CanvasObject {
 					return
 				}
 
-				ed.close()
-				d.fileTabs.Remove(t)
-				delete(d.openEditors, t)
+				d.closeTab(t)
 			}, d.win)
 	}
+	for _, tabs := range []*container.DocTabs{d.fileTabs, d.sideTabs} {
+		side := tabs
+		side.CloseIntercept = closeIntercept
+		side.OnSelected = func(*container.TabItem) {
+			d.activeTabs = side
+		}
+	}
 
-	return container.NewStack(d.fileTabs)
+	d.editorPanel = container.NewStack(d.fileTabs)
+	return d.editorPanel
 }
 
 func (d *defyne) makeFilesPanel() *xWidget.FileTree {
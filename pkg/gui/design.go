@@ -0,0 +1,179 @@
+package gui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+// designPropertyPrefix starts the metadata keys that describe a design.
+const designPropertyPrefix = "design."
+
+// The metadata of the root object of a design can set how the generated code shows it, using these keys.
+const (
+	DesignKind     = "design.kind"     // one of DesignContent, DesignDialog or DesignWindow
+	DesignTitle    = "design.title"    // the title of the dialog or window
+	DesignConfirm  = "design.confirm"  // the text of a dialog confirm button, a dialog with none only has a dismiss button
+	DesignDismiss  = "design.dismiss"  // the text of the button that dismisses a dialog
+	DesignOnClosed = "design.onClosed" // the name of a handler method called when a dialog is closed
+	DesignWidth    = "design.width"    // the initial width of the dialog or window
+	DesignHeight   = "design.height"   // the initial height of the dialog or window
+	DesignFixed    = "design.fixed"    // "true" if a window cannot be resized
+
+	// DesignFocus is the variable name of the widget to focus when the design is shown.
+	// Fyne moves the focus with Tab in the order of the layout, so this cannot set a tab order.
+	DesignFocus = "design.focus"
+	// DesignShortcutPrefix starts keys that bind a keyboard shortcut, such as "design.shortcut.Ctrl+S",
+	// to the name of the handler method that it calls
+	DesignShortcutPrefix = "design.shortcut."
+)
+
+// The kinds of design that can be set for DesignKind.
+const (
+	DesignContent = ""
+	DesignDialog  = "dialog"
+	DesignWindow  = "window"
+)
+
+// IsDesignProperty returns true if the metadata key describes the design, rather than the object it is stored on.
+func IsDesignProperty(key string) bool {
+	return strings.HasPrefix(key, designPropertyPrefix)
+}
+
+// designProperties returns the design settings, and position in a "Free" layout, within the metadata of an object.
+// These are kept for objects that otherwise store their settings in the object data, it returns nil if there are none.
+func designProperties(props map[string]string) map[string]string {
+	var ret map[string]string
+	for k, v := range props {
+		if !IsDesignProperty(k) && !guidefs.IsFreeProperty(k) {
+			continue
+		}
+
+		if ret == nil {
+			ret = make(map[string]string)
+		}
+		ret[k] = v
+	}
+	return ret
+}
+
+func decodeDesignProperties(m map[string]interface{}, props map[string]string) {
+	unpacked, ok := m["Properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for k, v := range unpacked {
+		if s, ok := v.(string); ok && (IsDesignProperty(k) || guidefs.IsFreeProperty(k)) {
+			props[k] = s
+		}
+	}
+}
+
+// designHandlers returns the handler methods that the dialog and keyboard shortcuts of a design call.
+func designHandlers(obj fyne.CanvasObject, d DefyneContext) map[string]string {
+	props := d.Metadata()[obj]
+	handlers := make(map[string]string)
+	keyboardHandlers(props, handlers)
+
+	if name := props[DesignOnClosed]; props[DesignKind] == DesignDialog && name != "" {
+		if props[DesignConfirm] == "" {
+			handlers[name] = "()"
+		} else {
+			handlers[name] = "(confirmed bool)"
+		}
+	}
+	return handlers
+}
+
+// designPackages returns the packages that the code showing a design uses.
+func designPackages(obj fyne.CanvasObject, d DefyneContext) []string {
+	props := d.Metadata()[obj]
+	var pkgs []string
+	if props[DesignKind] == DesignDialog {
+		pkgs = append(pkgs, "dialog")
+	}
+	if len(Shortcuts(props)) > 0 {
+		pkgs = append(pkgs, "driver/desktop")
+	}
+	return pkgs
+}
+
+// designCode returns the functions that show a dialog or open a window for a design.
+// The upper parameter is the name of the design as used in the `new...GUI()` constructor.
+func designCode(obj fyne.CanvasObject, d DefyneContext, upper string) string {
+	props := d.Metadata()[obj]
+	size := ""
+	w, errW := strconv.ParseFloat(props[DesignWidth], 32)
+	h, errH := strconv.ParseFloat(props[DesignHeight], 32)
+	if errW == nil && errH == nil && w > 0 && h > 0 {
+		size = fmt.Sprintf("fyne.NewSize(%s, %s)", strconv.FormatFloat(w, 'f', -1, 32),
+			strconv.FormatFloat(h, 'f', -1, 32))
+	}
+
+	str := &strings.Builder{}
+	switch props[DesignKind] {
+	case DesignDialog:
+		dismiss := props[DesignDismiss]
+		if dismiss == "" {
+			dismiss = "OK"
+		}
+		// shortcuts are added to the parent window, so they are removed again when the dialog closes
+		removeShortcuts := hasWindowSetup(obj, d) && len(Shortcuts(props)) > 0
+		handler := props[DesignOnClosed]
+		fmt.Fprintf(str, "\n// show%sDialog shows the design in a dialog over the parent window.\n", upper)
+		fmt.Fprintf(str, "func show%sDialog(parent fyne.Window) {\n\tg := new%sGUI()\n", upper, upper)
+		if confirm := props[DesignConfirm]; confirm != "" {
+			callback := "nil"
+			if removeShortcuts {
+				call := ""
+				if handler != "" {
+					call = "\t\tg." + handler + "(ok)\n"
+				}
+				callback = "func(ok bool) {\n\t\tg.removeShortcuts(parent)\n" + call + "\t}"
+			} else if handler != "" {
+				callback = "g." + handler
+			}
+			fmt.Fprintf(str, "\td := dialog.NewCustomConfirm(%q, %q, %q, g.makeUI(), %s, parent)\n",
+				props[DesignTitle], confirm, dismiss, callback)
+		} else {
+			fmt.Fprintf(str, "\td := dialog.NewCustom(%q, %q, g.makeUI(), parent)\n", props[DesignTitle], dismiss)
+			if removeShortcuts {
+				call := ""
+				if handler != "" {
+					call = "\t\tg." + handler + "()\n"
+				}
+				fmt.Fprintf(str, "\td.SetOnClosed(func() {\n\t\tg.removeShortcuts(parent)\n%s\t})\n", call)
+			} else if handler != "" {
+				fmt.Fprintf(str, "\td.SetOnClosed(g.%s)\n", handler)
+			}
+		}
+		if size != "" {
+			fmt.Fprintf(str, "\td.Resize(%s)\n", size)
+		}
+		str.WriteString("\td.Show()\n")
+		if hasWindowSetup(obj, d) {
+			str.WriteString("\tg.setupWindow(parent)\n")
+		}
+		str.WriteString("}\n")
+	case DesignWindow:
+		fmt.Fprintf(str, "\n// new%sWindow creates a window showing the design, call Show() to display it.\n", upper)
+		fmt.Fprintf(str, "func new%sWindow(a fyne.App) fyne.Window {\n\tg := new%sGUI()\n", upper, upper)
+		fmt.Fprintf(str, "\tw := a.NewWindow(%q)\n\tw.SetContent(g.makeUI())\n", props[DesignTitle])
+		if size != "" {
+			fmt.Fprintf(str, "\tw.Resize(%s)\n", size)
+		}
+		if props[DesignFixed] == "true" {
+			str.WriteString("\tw.SetFixedSize(true)\n")
+		}
+		if hasWindowSetup(obj, d) {
+			str.WriteString("\tg.setupWindow(w)\n")
+		}
+		str.WriteString("\treturn w\n}\n")
+	}
+	return str.String()
+}
@@ -0,0 +1,47 @@
+package gui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportDesignKinds(t *testing.T) {
+	s := container.NewHSplit(widget.NewLabel("A"), widget.NewLabel("B"))
+	meta := map[fyne.CanvasObject]map[string]string{s: {
+		DesignKind: DesignDialog, DesignTitle: "Settings", DesignConfirm: "Save", DesignOnClosed: "settingsClosed"}}
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeObject(s, newTestContext(meta), &buf))
+	dec := newTestContext(nil)
+	obj, _, err := DecodeObject(&buf, dec)
+	require.Nil(t, err)
+	assert.Equal(t, DesignDialog, dec.meta[obj][DesignKind])
+	assert.Equal(t, "settingsClosed", dec.meta[obj][DesignOnClosed])
+
+	var code strings.Builder
+	require.Nil(t, ExportGo(s, newTestContext(meta), "settings", &code))
+	assert.Contains(t, code.String(), "func showSettingsDialog(parent fyne.Window) {")
+	assert.Contains(t, code.String(), `dialog.NewCustomConfirm("Settings", "Save", "OK", g.makeUI(), g.settingsClosed, parent)`)
+	assert.Contains(t, code.String(), `"fyne.io/fyne/v2/dialog"`)
+
+	code.Reset()
+	count, _ := ExportHandlerStubs(s, newTestContext(meta), "settings", nil, &code)
+	assert.Equal(t, 1, count)
+	assert.Contains(t, code.String(), "func (g *settingsGui) settingsClosed(confirmed bool) {\n}")
+
+	meta[s] = map[string]string{DesignKind: DesignWindow, DesignTitle: "Tools", DesignWidth: "300", DesignHeight: "200",
+		DesignFixed: "true"}
+	code.Reset()
+	require.Nil(t, ExportGo(s, newTestContext(meta), "tools", &code))
+	assert.Contains(t, code.String(), "func newToolsWindow(a fyne.App) fyne.Window {")
+	assert.Contains(t, code.String(), "w.Resize(fyne.NewSize(300, 200))")
+	assert.Contains(t, code.String(), "w.SetFixedSize(true)")
+}
@@ -32,11 +32,28 @@ func EditorFor(o fyne.CanvasObject, d DefyneContext, refresh func([]*widget.Form
 		onchanged = func() {}
 	}
 
-	if match := guidefs.Lookup(clazz); match != nil {
+	match := guidefs.Lookup(clazz)
+	if match == nil {
+		return nil
+	}
+	if match.IsContainer() {
 		return match.Edit(o, d, refresh, onchanged)
 	}
 
-	return nil
+	// choosing a style or string changes other properties, so the form is rebuilt to show them
+	rebuild := func() {
+		onchanged()
+		refresh(EditorFor(o, d, refresh, onchanged))
+	}
+	style := append(guidefs.StyleItems(o, d, rebuild), guidefs.StringItems(o, d, rebuild)...)
+	var events []*widget.FormItem
+	if _, ok := o.(fyne.Widget); ok {
+		events = guidefs.EventItems(o, d, onchanged)
+	}
+	items := match.Edit(o, d, func(items []*widget.FormItem) {
+		refresh(append(append(style, items...), events...))
+	}, onchanged)
+	return append(append(style, items...), events...)
 }
 
 // GoStringFor generates the Go code for the given widget
@@ -44,12 +61,7 @@ func GoStringFor(o fyne.CanvasObject, d DefyneContext, defs map[string]string) s
 	guidefs.InitOnce()
 
 	name := reflect.TypeOf(o).String()
-
-	if match := guidefs.Lookup(name); match != nil {
-		return match.Gostring(o, d, defs)
-	}
-
-	return ""
+	return guidefs.GoString(name, o, d, defs)
 }
 
 func getTypeOf(o fyne.CanvasObject) (string, string) {
@@ -11,13 +11,14 @@ import (
 	"github.com/fyne-io/defyne/internal/guidefs"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
 )
 
 // ExportGo generates a full Go package for the given object and writes it to the provided file handle
 func ExportGo(obj fyne.CanvasObject, d DefyneContext, name string, w io.Writer) error {
 	guidefs.InitOnce()
 
-	packagesList := packagesRequired(obj, d)
+	packagesList := addPackages(packagesRequired(obj, d), designPackages(obj, d))
 
 	// Really this needs to be a full dependency analysis but for now a simple sort of widgets before containers may work
 	varListWidgets, varListContainers := varsRequired(obj, d)
@@ -34,7 +35,7 @@ func ExportGo(obj fyne.CanvasObject, d DefyneContext, name string, w io.Writer)
 func ExportGoPreview(obj fyne.CanvasObject, d DefyneContext, w io.Writer) error {
 	guidefs.InitOnce()
 
-	packagesList := packagesRequired(obj, d)
+	packagesList := addPackages(packagesRequired(obj, d), designPackages(obj, d))
 	packagesList = append(packagesList, "app")
 
 	// Really this needs to be a full dependency analysis but for now a simple sort of widgets before containers may work
@@ -44,28 +45,135 @@ func ExportGoPreview(obj fyne.CanvasObject, d DefyneContext, w io.Writer) error
 
 	code := exportCode(packagesList, append(varListWidgets, varListContainers...), obj, d, "main")
 
+	stubs := &strings.Builder{}
+	_, err := ExportHandlerStubs(obj, d, "main", nil, stubs)
+	if err != nil {
+		return err
+	}
+	code += stubs.String()
+
+	setup := ""
+	if hasWindowSetup(obj, d) {
+		setup = "\tgui.setupWindow(myWindow)\n"
+	}
 	code += `
 func main() {
 	myApp := app.New()
 	myWindow := myApp.NewWindow("Hello")
 	gui := newGUI()
 	myWindow.SetContent(gui.makeUI())
-	myWindow.ShowAndRun()
+` + setup + `	myWindow.ShowAndRun()
 }
 `
-	_, err := w.Write([]byte(code))
+	_, err = w.Write([]byte(code))
 
 	return err
 }
 
+// HandlerReceiver returns the name of the type that the generated code, and handler methods, of a design use.
+func HandlerReceiver(name string) string {
+	if name == "main" {
+		return "gui"
+	}
+
+	return name + "Gui"
+}
+
+// Handlers returns the handler methods that the actions of a design call, mapped to their parameters and results,
+// such as "(v string)".
+func Handlers(obj fyne.CanvasObject, d DefyneContext) map[string]string {
+	guidefs.InitOnce()
+
+	handlers := handlersRequired(obj, d)
+	for n, sig := range designHandlers(obj, d) {
+		handlers[n] = sig
+	}
+	return handlers
+}
+
+// CheckHandlers returns an error if a handler method is declared by two sets of handlers, such as those of a design
+// and its menu, with different signatures.
+func CheckHandlers(a, b map[string]string) error {
+	names := make([]string, 0, len(a))
+	for n := range a {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		if sig, ok := b[n]; ok && sig != a[n] {
+			return fmt.Errorf("handler %s is used as %s%s and %s%s", n, n, a[n], n, sig)
+		}
+	}
+	return nil
+}
+
+// ExportHandlerStubs writes empty methods for each handler that the actions of a design call, unless it is listed
+// in existing. It returns the number of methods written.
+func ExportHandlerStubs(obj fyne.CanvasObject, d DefyneContext, name string, existing []string, w io.Writer) (int, error) {
+	return writeHandlerStubs(Handlers(obj, d), name, existing, w)
+}
+
+// writeHandlerStubs writes an empty method, on the receiver of the named design, for each of the handlers that is not
+// listed in existing. It returns the number of methods written.
+func writeHandlerStubs(handlers map[string]string, name string, existing []string, w io.Writer) (int, error) {
+	for _, e := range existing {
+		delete(handlers, e)
+	}
+	names := make([]string, 0, len(handlers))
+	for n := range handlers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		sig := handlers[n]
+		body := ""
+		if strings.HasSuffix(sig, ") error") {
+			body = "\treturn nil\n"
+		} else if strings.HasSuffix(sig, ") color.Color") {
+			body = "\treturn color.Transparent\n"
+		} else if strings.Contains(sig, ") ") { // other results cannot be guessed
+			body = "\tpanic(\"not implemented\")\n"
+		}
+
+		_, err := fmt.Fprintf(w, "\nfunc (g *%s) %s%s {\n%s}\n", HandlerReceiver(name), n, sig, body)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(names), nil
+}
+
+// HandlerStubImports returns the import paths that the methods written by ExportHandlerStubs, for the same
+// existing methods, use in their signatures.
+func HandlerStubImports(obj fyne.CanvasObject, d DefyneContext, existing []string) []string {
+	imports := handlerImportsRequired(obj, d)
+	for _, e := range existing {
+		delete(imports, e)
+	}
+
+	used := make(map[string]bool)
+	for _, paths := range imports {
+		for _, p := range paths {
+			used[p] = true
+		}
+	}
+	return sortedKeys(used)
+}
+
 func exportCode(pkgs, vars []string, obj fyne.CanvasObject, d DefyneContext, name string) string {
 	for i := 0; i < len(pkgs); i++ {
-		if pkgs[i] != "fmt" && pkgs[i] != "net/url" && pkgs[i] != "image/color" {
+		if pkgs[i] != "errors" && pkgs[i] != "fmt" && pkgs[i] != "net/url" && pkgs[i] != "image/color" {
 			pkgs[i] = "fyne.io/fyne/v2/" + pkgs[i]
 		}
 
 		pkgs[i] = fmt.Sprintf(`	"%s"`, pkgs[i])
 	}
+	// the types used by actions are imported by their full path
+	for _, p := range eventPackagesRequired(obj, d) {
+		pkgs = addPackages(pkgs, []string{fmt.Sprintf(`	"%s"`, p)})
+	}
 
 	defs := make(map[string]string)
 
@@ -86,10 +194,9 @@ func exportCode(pkgs, vars []string, obj fyne.CanvasObject, d DefyneContext, nam
 		setup += "g." + name + " = " + defs[name] + "\n"
 	}
 
-	guiName := "gui"
+	guiName := HandlerReceiver(name)
 	guiNameUpper := ""
 	if name != "main" {
-		guiName = name + "Gui"
 		guiNameUpper = strings.ToUpper(string([]byte{name[0]})) + name[1:]
 	}
 	code := fmt.Sprintf(`// auto-generated
@@ -120,6 +227,8 @@ func (g *%s) makeUI() fyne.CanvasObject {
 		strings.Join(vars, "\n"),
 		guiNameUpper, guiName, guiName, guiName,
 		setup, main)
+	code += designCode(obj, d, guiNameUpper)
+	code += keyboardCode(obj, d, guiName)
 
 	formatted, err := format.Source([]byte(code))
 	if err != nil {
@@ -129,6 +238,23 @@ func (g *%s) makeUI() fyne.CanvasObject {
 	return string(formatted)
 }
 
+// addPackages returns the list of packages with any of the extra packages that it does not already include.
+func addPackages(pkgs, extra []string) []string {
+	for _, p := range extra {
+		found := false
+		for _, exists := range pkgs {
+			if p == exists {
+				found = true
+				break
+			}
+		}
+		if !found {
+			pkgs = append(pkgs, p)
+		}
+	}
+	return pkgs
+}
+
 func packagesRequired(obj fyne.CanvasObject, d DefyneContext) []string {
 	ret := []string{"container"}
 	var objs []fyne.CanvasObject
@@ -214,11 +340,66 @@ func varsRequired(obj fyne.CanvasObject, d DefyneContext) (widgets, containers [
 			}
 		}
 
+		for item, class := range guidefs.ToolbarItemNames(obj, d) {
+			widgets = append(widgets, item+" "+class)
+		}
 		if name != "" {
 			_, class := getTypeOf(obj)
-			widgets = append(widgets, name+" "+class)
+			if _, ok := obj.(*widget.Toolbar); ok {
+				// the toolbar uses its named items so it must be created after them
+				containers = append(containers, name+" "+class)
+			} else {
+				widgets = append(widgets, name+" "+class)
+			}
 		}
 	}
 
 	return
 }
+
+func handlersRequired(obj fyne.CanvasObject, d DefyneContext) map[string]string {
+	handlers := guidefs.EventHandlers(obj, d)
+	for _, child := range childObjects(obj) {
+		for n, sig := range handlersRequired(child, d) {
+			handlers[n] = sig
+		}
+	}
+	return handlers
+}
+
+// handlerImportsRequired returns the import paths that the handler methods of obj and its children use, by name.
+func handlerImportsRequired(obj fyne.CanvasObject, d DefyneContext) map[string][]string {
+	imports := guidefs.EventImports(obj, d)
+	for _, child := range childObjects(obj) {
+		for n, paths := range handlerImportsRequired(child, d) {
+			imports[n] = paths
+		}
+	}
+	return imports
+}
+
+// eventPackagesRequired returns the import paths that the actions of obj and its children use.
+func eventPackagesRequired(obj fyne.CanvasObject, d DefyneContext) []string {
+	pkgs := guidefs.EventPackages(obj, d)
+	for _, child := range childObjects(obj) {
+		pkgs = addPackages(pkgs, eventPackagesRequired(child, d))
+	}
+	return pkgs
+}
+
+func childObjects(obj fyne.CanvasObject) []fyne.CanvasObject {
+	var objs []fyne.CanvasObject
+	if c, ok := obj.(*fyne.Container); ok {
+		objs = c.Objects
+	} else if info := guidefs.Lookup(reflect.TypeOf(obj).String()); info != nil && info.IsContainer() {
+		objs = info.Children(obj)
+	}
+
+	children := make([]fyne.CanvasObject, 0, len(objs))
+	for _, child := range objs {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	return children
+}
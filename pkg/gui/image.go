@@ -0,0 +1,95 @@
+package gui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/software"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+// ImageOptions configures how a design is rendered to an image.
+type ImageOptions struct {
+	// Size is the size of the design, before scaling. If it is zero the minimum size of the design is used.
+	Size fyne.Size
+	// Scale is the number of pixels per unit of size, defaulting to 1.
+	Scale float32
+	// Theme is used to draw the design, if nil then the default theme is used.
+	Theme fyne.Theme
+	// Variant is the light or dark variant of the theme that will be shown.
+	Variant fyne.ThemeVariant
+}
+
+// RenderTheme returns the theme that images are drawn with, which shows Theme in the requested variant.
+// A DefyneContext used to load a design for rendering should return it, so that theme colours match.
+func (o ImageOptions) RenderTheme() fyne.Theme {
+	th := o.Theme
+	if th == nil {
+		th = theme.DefaultTheme()
+	}
+	return guidefs.NewVariantTheme(th, o.Variant)
+}
+
+// RenderImage draws the object using the software renderer, so it can be used without a display.
+// The object should not be part of a visible window as it will be resized and themed for rendering.
+func RenderImage(obj fyne.CanvasObject, opts ImageOptions) image.Image {
+	guidefs.InitOnce()
+
+	th := opts.RenderTheme()
+	bg := canvas.NewRectangle(th.Color(theme.ColorNameBackground, opts.Variant))
+	content := container.NewThemeOverride(container.NewStack(bg, obj), th)
+
+	c := software.NewCanvas()
+	c.SetPadded(false)
+	if opts.Scale > 0 {
+		c.SetScale(opts.Scale)
+	}
+	c.SetContent(content)
+
+	size := opts.Size
+	if size.IsZero() {
+		size = content.MinSize()
+	}
+	c.Resize(size)
+	return c.Capture()
+}
+
+// ExportPNG renders the object, as described by RenderImage, and writes it to the file handle in PNG format.
+func ExportPNG(obj fyne.CanvasObject, opts ImageOptions, w io.Writer) error {
+	return png.Encode(w, RenderImage(obj, opts))
+}
+
+// ExportSVG renders the object and writes it to the file handle as an SVG document that embeds the PNG image.
+// The document contains a single bitmap, as a base64 data URI, so it does not scale like vector graphics;
+// it is provided for tools that only accept SVG files.
+func ExportSVG(obj fyne.CanvasObject, opts ImageOptions, w io.Writer) error {
+	img := RenderImage(obj, opts)
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">
+  <image width="%d" height="%d" href="data:image/png;base64,`, width, height, width, height, width, height)
+	if err != nil {
+		return err
+	}
+
+	enc := base64.NewEncoder(base64.StdEncoding, w)
+	err = png.Encode(enc, img)
+	if err != nil {
+		return err
+	}
+	err = enc.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\"/>\n</svg>\n")
+	return err
+}
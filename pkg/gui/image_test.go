@@ -0,0 +1,57 @@
+package gui
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderImage(t *testing.T) {
+	test.NewApp()
+
+	for _, v := range []fyne.ThemeVariant{theme.VariantLight, theme.VariantDark} {
+		img := RenderImage(widget.NewLabel("Hi"), ImageOptions{Size: fyne.NewSize(100, 50), Scale: 2, Variant: v})
+		assert.Equal(t, 200, img.Bounds().Dx())
+		assert.Equal(t, 100, img.Bounds().Dy())
+		assert.Equal(t, color.NRGBAModel.Convert(theme.DefaultTheme().Color(theme.ColorNameBackground, v)),
+			color.NRGBAModel.Convert(img.At(199, 99)))
+	}
+}
+
+func TestExportPNGThemeColor(t *testing.T) {
+	test.NewApp()
+	r := canvas.NewRectangle(color.Black)
+	meta := map[fyne.CanvasObject]map[string]string{r: {"color.FillColor": string(theme.ColorNameForeground)}}
+	var data bytes.Buffer
+	require.Nil(t, EncodeObject(r, newTestContext(meta), &data))
+
+	opts := ImageOptions{Size: fyne.NewSize(20, 10), Variant: theme.VariantDark}
+	ctx := newTestContext(nil)
+	ctx.th = opts.RenderTheme()
+	obj, _, err := DecodeObject(&data, ctx)
+	require.Nil(t, err)
+
+	var buf bytes.Buffer
+	require.Nil(t, ExportPNG(obj, opts, &buf))
+	img, err := png.Decode(&buf)
+	require.Nil(t, err)
+	assert.Equal(t, 20, img.Bounds().Dx())
+	assert.Equal(t, color.NRGBAModel.Convert(theme.DefaultTheme().Color(theme.ColorNameForeground, theme.VariantDark)),
+		color.NRGBAModel.Convert(img.At(10, 5)))
+
+	var svg strings.Builder
+	require.Nil(t, ExportSVG(obj, opts, &svg))
+	assert.Contains(t, svg.String(), `width="20" height="10"`)
+	assert.Contains(t, svg.String(), "data:image/png;base64,")
+}
@@ -12,8 +12,8 @@ import (
 	"time"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/fyne-io/defyne/internal/guidefs"
@@ -62,11 +62,10 @@ func DecodeObject(r io.Reader, d DefyneContext) (fyne.CanvasObject, map[fyne.Can
 		return nil, nil, err
 	}
 
-	meta := make(map[fyne.CanvasObject]map[string]string)
 	root := data.(map[string]interface{})
 
 	obj, err := DecodeMap(root, d)
-	return obj, meta, err
+	return obj, d.Metadata(), err
 }
 
 // DecodeMap returns a tree of `CanvasObject` elements from the provided JSON map and
@@ -104,7 +103,12 @@ func DecodeMap(m map[string]interface{}, d DefyneContext) (fyne.CanvasObject, er
 				}
 			}
 		}
-		obj.Layout = guidefs.Layouts[name].Create(obj, d)
+		if lay, ok := guidefs.LookupLayout(d, name); ok {
+			obj.Layout = lay.Create(obj, d)
+		} else {
+			fyne.LogError("Unknown layout "+name+", is it missing from "+guidefs.CustomLayoutsFile+"?", nil)
+			obj.Layout = guidefs.Layouts["VBox"].Create(obj, d)
+		}
 		if name, ok := m["Name"]; ok {
 			props["name"] = name.(string)
 		}
@@ -141,6 +145,7 @@ func DecodeMap(m map[string]interface{}, d DefyneContext) (fyne.CanvasObject, er
 			obj.SelectIndex(int(index.(float64)))
 		}
 
+		decodeDesignProperties(m, props)
 		d.Metadata()[obj] = props
 		return obj, nil
 	case "*container.Scroll":
@@ -159,6 +164,7 @@ func DecodeMap(m map[string]interface{}, d DefyneContext) (fyne.CanvasObject, er
 			props["name"] = name.(string)
 		}
 
+		decodeDesignProperties(m, props)
 		d.Metadata()[obj] = props
 		return obj, nil
 	case "*container.Split":
@@ -184,6 +190,27 @@ func DecodeMap(m map[string]interface{}, d DefyneContext) (fyne.CanvasObject, er
 			props["name"] = name.(string)
 		}
 
+		decodeDesignProperties(m, props)
+		d.Metadata()[obj] = props
+		return obj, nil
+	case "*widget.Card":
+		info := m["Struct"].(map[string]interface{})
+		title, _ := info["Title"].(string)
+		subtitle, _ := info["Subtitle"].(string)
+
+		// designs from before cards held content did not store it, and always showed a placeholder label
+		var content fyne.CanvasObject = widget.NewLabel("Content here")
+		if data, ok := info["Content"].(map[string]interface{}); ok && data["Type"] != nil {
+			content, _ = DecodeMap(data, d)
+		}
+		obj := widget.NewCard(title, subtitle, content)
+
+		props := map[string]string{}
+		if name, ok := m["Name"]; ok {
+			props["name"] = name.(string)
+		}
+
+		decodeDesignProperties(m, props)
 		d.Metadata()[obj] = props
 		return obj, nil
 	case "*container.ThemeOverride":
@@ -198,11 +225,7 @@ func DecodeMap(m map[string]interface{}, d DefyneContext) (fyne.CanvasObject, er
 		if !ok || data == "" {
 			data = "{}"
 		}
-		fallback := d.Theme()
-		if fallback == nil {
-			fallback = theme.DefaultTheme()
-		}
-		th, err := theme.FromJSONWithFallback(data.(string), fallback)
+		th, err := guidefs.ThemeFromJSON(data.(string), d)
 		if err != nil {
 			fyne.LogError("Theme decode error", err)
 		}
@@ -215,6 +238,7 @@ func DecodeMap(m map[string]interface{}, d DefyneContext) (fyne.CanvasObject, er
 			props["name"] = name.(string)
 		}
 
+		decodeDesignProperties(m, props)
 		d.Metadata()[obj] = props
 		return obj, nil
 	}
@@ -241,8 +265,12 @@ func DecodeMap(m map[string]interface{}, d DefyneContext) (fyne.CanvasObject, er
 			}
 		}
 	}
+	if _, ok := obj.(*widget.Toolbar); ok {
+		decodeToolbarProperties(m, props)
+	}
 
 	d.Metadata()[obj] = props
+	guidefs.Restore(obj, d)
 	return obj, nil
 }
 
@@ -301,33 +329,43 @@ func EncodeMap(obj fyne.CanvasObject, d DefyneContext) (interface{}, error) {
 		node.Struct["Items"] = items
 		node.Struct["MultiOpen"] = c.MultiOpen
 
+		node.Properties = designProperties(props)
 		return &node, nil
 	case *widget.Button:
 		if c.Icon == nil {
 			return encodeWidget(c, name, actions, props), nil
 		}
 
-		ic := c.Icon
-		c.Icon = guidefs.WrapResource(c.Icon)
-		wid := encodeWidget(c, name, actions, props)
-		go func() { // TODO find a better way to reset this after encoding
-			time.Sleep(time.Millisecond * 100)
-			c.Icon = ic
-		}()
-		return wid, nil
+		return encodeWidgetResource(c, "Icon", c.Icon, name, actions, props)
 	case *widget.Icon:
 		if c.Resource == nil {
 			return encodeWidget(c, name, actions, props), nil
 		}
 
-		ic := c.Resource
-		c.Resource = guidefs.WrapResource(c.Resource)
-		wid := encodeWidget(c, name, actions, props)
-		go func() { // TODO find a better way to reset this after encoding
-			time.Sleep(time.Millisecond * 100)
-			c.Resource = ic
-		}()
-		return wid, nil
+		return encodeWidgetResource(c, "Resource", c.Resource, name, actions, props)
+	case *canvas.Image:
+		node := &cntObj{Struct: make(map[string]interface{})}
+		node.Type = "*canvas.Image"
+		node.Name = name
+		node.Properties = encodeWidget(c, name, nil, props).Properties
+
+		node.Struct["Hidden"] = c.Hidden
+		node.Struct["FillMode"] = c.FillMode
+		node.Struct["ScaleMode"] = c.ScaleMode
+		node.Struct["Translucency"] = c.Translucency
+
+		return &node, nil
+	case *canvas.Raster:
+		// the pixel function cannot be encoded, it is a handler method named in the properties
+		node := &cntObj{Struct: make(map[string]interface{})}
+		node.Type = "*canvas.Raster"
+		node.Name = name
+		node.Properties = encodeWidget(c, name, nil, props).Properties
+
+		node.Struct["Hidden"] = c.Hidden
+		node.Struct["Translucency"] = c.Translucency
+
+		return &node, nil
 	case *widget.Toolbar:
 		node := &cntObj{Struct: make(map[string]interface{})}
 		node.Type = "*widget.Toolbar"
@@ -340,6 +378,12 @@ func EncodeMap(obj fyne.CanvasObject, d DefyneContext) (interface{}, error) {
 			case *widget.ToolbarAction:
 				data["Icon"] = guidefs.WrapResource(t.Icon)
 				data["Type"] = "Action"
+				if action := props[guidefs.ToolbarItemKey(i, guidefs.ToolbarItemAction)]; action != "" {
+					data["Action"] = action
+				}
+				if name := props[guidefs.ToolbarItemKey(i, guidefs.ToolbarItemName)]; name != "" {
+					data["Name"] = name
+				}
 			case *widget.ToolbarSeparator:
 				data["Type"] = "Separator"
 			case *widget.ToolbarSpacer:
@@ -371,6 +415,7 @@ func EncodeMap(obj fyne.CanvasObject, d DefyneContext) (interface{}, error) {
 		node.Struct["Items"] = items
 		node.Struct["SelectedIndex"] = c.SelectedIndex()
 
+		node.Properties = designProperties(props)
 		return &node, nil
 	case *container.Scroll:
 		node := &cntObj{Struct: make(map[string]interface{})}
@@ -380,6 +425,7 @@ func EncodeMap(obj fyne.CanvasObject, d DefyneContext) (interface{}, error) {
 
 		node.Struct["Content"], _ = EncodeMap(c.Content, d)
 
+		node.Properties = designProperties(props)
 		return &node, nil
 	case *container.ThemeOverride:
 		node := &cntObj{Struct: make(map[string]interface{})}
@@ -389,6 +435,7 @@ func EncodeMap(obj fyne.CanvasObject, d DefyneContext) (interface{}, error) {
 		node.Struct["Content"], _ = EncodeMap(c.Content, d)
 		node.Struct["Theme"] = d.Metadata()[c]["data"]
 
+		node.Properties = designProperties(props)
 		return &node, nil
 	case *container.Split:
 		node := &cntObj{Struct: make(map[string]interface{})}
@@ -400,6 +447,18 @@ func EncodeMap(obj fyne.CanvasObject, d DefyneContext) (interface{}, error) {
 		node.Struct["Leading"], _ = EncodeMap(c.Leading, d)
 		node.Struct["Trailing"], _ = EncodeMap(c.Trailing, d)
 
+		node.Properties = designProperties(props)
+		return &node, nil
+	case *widget.Card:
+		node := &cntObj{Struct: make(map[string]interface{})}
+		node.Type = "*widget.Card"
+		node.Struct["Title"] = c.Title
+		node.Struct["Subtitle"] = c.Subtitle
+		node.Name = name
+
+		node.Struct["Content"], _ = EncodeMap(c.Content, d)
+
+		node.Properties = designProperties(props)
 		return &node, nil
 	case fyne.Widget:
 		if form, ok := c.(*widget.Form); ok {
@@ -423,6 +482,9 @@ func EncodeMap(obj fyne.CanvasObject, d DefyneContext) (interface{}, error) {
 				node.Layout = "VBox"
 			}
 		}
+		if guidefs.IsCustomLayout(d, props["layout"]) {
+			node.Layout = props["layout"]
+		}
 		for _, o := range c.Objects {
 			enc, _ := EncodeMap(o, d)
 			node.Objects = append(node.Objects, enc)
@@ -431,7 +493,7 @@ func EncodeMap(obj fyne.CanvasObject, d DefyneContext) (interface{}, error) {
 		return &node, nil
 	}
 
-	return &canvObj{Type: reflect.TypeOf(obj).String(), Name: name, Struct: obj}, nil
+	return encodeWidget(obj, name, actions, props), nil
 }
 
 func encodeForm(obj *widget.Form, name string) interface{} {
@@ -478,6 +540,25 @@ func encodeWidget(obj fyne.CanvasObject, name string, actions map[string]string,
 	return w
 }
 
+// encodeWidgetResource encodes a widget that holds a resource, storing the icon name for the field
+// so that the live widget does not need to be changed while it is encoded.
+func encodeWidgetResource(obj fyne.CanvasObject, field string, res fyne.Resource, name string,
+	actions map[string]string, meta map[string]string) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fields[field] = guidefs.IconName(res)
+
+	wid := encodeWidget(obj, name, actions, meta)
+	wid.Struct = nil
+	return &cntObj{canvObj: *wid, Struct: fields}, nil
+}
+
 func decodeAccordionItem(m map[string]interface{}, d DefyneContext) *widget.AccordionItem {
 	f := &widget.AccordionItem{}
 	if str, ok := m["Title"]; ok {
@@ -549,6 +630,25 @@ func decodePosition(m map[string]interface{}) fyne.Position {
 	return fyne.NewPos(float32(x), float32(y))
 }
 
+// decodeToolbarProperties reads the action and variable name of each toolbar item into the toolbar's metadata.
+func decodeToolbarProperties(m map[string]interface{}, props map[string]string) {
+	info, _ := m["Struct"].(map[string]interface{})
+	items, _ := info["Items"].([]interface{})
+	for i, item := range items {
+		data, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if action, ok := data["Action"].(string); ok && action != "" {
+			props[guidefs.ToolbarItemKey(i, guidefs.ToolbarItemAction)] = action
+		}
+		if name, ok := data["Name"].(string); ok && name != "" {
+			props[guidefs.ToolbarItemKey(i, guidefs.ToolbarItemName)] = name
+		}
+	}
+}
+
 func decodeToolbarItem(m map[string]interface{}) widget.ToolbarItem {
 	if v, ok := m["Type"]; ok {
 		switch v {
@@ -586,7 +686,8 @@ func decodeFields(e reflect.Value, in map[string]interface{}, d DefyneContext) e
 		typeName := f.Type().String()
 		switch typeName {
 		case "fyne.TextAlign", "fyne.TextTruncation", "fyne.TextWrap", "widget.ButtonAlign", "widget.ButtonImportance",
-			"widget.ButtonIconPlacement", "widget.Importance", "widget.Orientation", "widget.ScrollDirection", "fyne.ScrollDirection":
+			"widget.ButtonIconPlacement", "widget.Importance", "widget.Orientation", "widget.ScrollDirection", "fyne.ScrollDirection",
+			"canvas.ImageFill", "canvas.ImageScale":
 			f.SetInt(int64(reflect.ValueOf(v).Float()))
 		case "fyne.TextStyle":
 			f.Set(reflect.ValueOf(decodeTextStyle(reflect.ValueOf(v).Interface().(map[string]interface{}))))
@@ -3,12 +3,18 @@ package gui
 import (
 	"bytes"
 	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	_ "fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/fyne-io/defyne/internal/guidefs"
@@ -68,11 +74,43 @@ var splitJSON = `{
 }
 `
 
+type testContext struct {
+	meta map[fyne.CanvasObject]map[string]string
+	th   fyne.Theme
+	root fyne.URI
+}
+
+func newTestContext(meta map[fyne.CanvasObject]map[string]string) *testContext {
+	if meta == nil {
+		meta = make(map[fyne.CanvasObject]map[string]string)
+	}
+	return &testContext{meta: meta}
+}
+
+// newProjectTestContext returns a context for a project in its own directory, so registries do not leak between tests.
+func newProjectTestContext(root fyne.URI, meta map[fyne.CanvasObject]map[string]string) *testContext {
+	c := newTestContext(meta)
+	c.root = root
+	return c
+}
+
+func (t *testContext) Metadata() map[fyne.CanvasObject]map[string]string {
+	return t.meta
+}
+
+func (t *testContext) Theme() fyne.Theme {
+	return t.th
+}
+
+func (t *testContext) ProjectRoot() fyne.URI {
+	return t.root
+}
+
 func TestDecodeObject(t *testing.T) {
 	guidefs.InitOnce()
 
 	buf := bytes.NewReader([]byte(fmt.Sprintf(labelJSON, "\n  \"Name\": \"myLabel\",")))
-	obj, meta, err := DecodeObject(buf)
+	obj, meta, err := DecodeObject(buf, newTestContext(nil))
 	assert.Nil(t, err)
 
 	l, ok := obj.(*widget.Label)
@@ -85,7 +123,7 @@ func TestDecodeObject(t *testing.T) {
 
 func TestDecodeSplit(t *testing.T) {
 	buf := bytes.NewReader([]byte(splitJSON))
-	obj, meta, err := DecodeObject(buf)
+	obj, meta, err := DecodeObject(buf, newTestContext(nil))
 	assert.Nil(t, err)
 
 	s, ok := obj.(*container.Split)
@@ -113,7 +151,7 @@ func TestEncodeObject(t *testing.T) {
 	meta := map[fyne.CanvasObject]map[string]string{l: props}
 
 	var buf bytes.Buffer
-	err := EncodeObject(l, meta, &buf)
+	err := EncodeObject(l, newTestContext(meta), &buf)
 	assert.Nil(t, err)
 	assert.Equal(t, fmt.Sprintf(labelJSON, "\n  \"Name\": \"myLabel\",")+"\n", buf.String())
 }
@@ -129,7 +167,477 @@ func TestEncodeSplit(t *testing.T) {
 	meta := map[fyne.CanvasObject]map[string]string{s: props}
 
 	var buf bytes.Buffer
-	err := EncodeObject(s, meta, &buf)
+	err := EncodeObject(s, newTestContext(meta), &buf)
 	assert.Nil(t, err)
 	assert.Equal(t, splitJSON, buf.String())
 }
+
+func TestEncodeDecodeButtonIcon(t *testing.T) {
+	guidefs.InitOnce()
+	icon := theme.DocumentSaveIcon()
+	b := widget.NewButtonWithIcon("Save", icon, nil)
+
+	var buf bytes.Buffer
+	err := EncodeObject(b, newTestContext(nil), &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, icon, b.Icon)
+	assert.Contains(t, buf.String(), `"Icon": "DocumentSaveIcon"`)
+
+	obj, _, err := DecodeObject(&buf, newTestContext(nil))
+	assert.Nil(t, err)
+	button, ok := obj.(*widget.Button)
+	require.True(t, ok)
+	assert.Equal(t, "Save", button.Text)
+	assert.Equal(t, "DocumentSaveIcon", guidefs.IconName(button.Icon))
+}
+
+func TestEncodeDecodeImage(t *testing.T) {
+	test.NewApp()
+	img := canvas.NewImageFromResource(nil)
+	img.FillMode = canvas.ImageFillOriginal
+	img.Translucency = 0.5
+
+	props := map[string]string{"name": "myImage", "minWidth": "32"}
+	meta := map[fyne.CanvasObject]map[string]string{img: props}
+
+	var buf bytes.Buffer
+	err := EncodeObject(img, newTestContext(meta), &buf)
+	assert.Nil(t, err)
+
+	obj, meta, err := DecodeObject(&buf, newTestContext(nil))
+	assert.Nil(t, err)
+	i, ok := obj.(*canvas.Image)
+	require.True(t, ok)
+	assert.Equal(t, canvas.ImageFillOriginal, i.FillMode)
+	assert.Equal(t, 0.5, i.Translucency)
+	assert.Equal(t, "myImage", meta[i]["name"])
+	assert.Equal(t, float32(32), i.MinSize().Width)
+}
+
+func TestExportResources(t *testing.T) {
+	test.NewApp()
+	dir := t.TempDir()
+	require.Nil(t, os.MkdirAll(filepath.Join(dir, "images"), 0755))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "images", "logo.png"), []byte("png"), 0644))
+	img := canvas.NewImageFromResource(nil)
+	meta := map[fyne.CanvasObject]map[string]string{img: {guidefs.ImageResourceProperty: "images/logo.png"}}
+	ctx := &testContext{meta: meta, root: storage.NewFileURI(dir)}
+
+	assert.Equal(t, []string{"images/logo.png"}, ImagesUsed(ctx.meta))
+	assert.Contains(t, GoStringFor(img, ctx, map[string]string{}), "Resource: resourceLogoPng")
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeObject(img, ctx, &buf))
+	paths, err := ImagesUsedInJSON(&buf)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"images/logo.png"}, paths)
+
+	var code strings.Builder
+	require.Nil(t, ExportResourcesGo(ctx, paths, &code))
+	assert.Contains(t, code.String(), "var resourceLogoPng = &fyne.StaticResource{")
+	assert.Contains(t, code.String(), `StaticName:    "logo.png",`)
+	assert.Contains(t, code.String(), `StaticContent: []byte("png"),`)
+}
+
+func TestExportThemeFonts(t *testing.T) {
+	test.NewApp()
+	dir := t.TempDir()
+	require.Nil(t, os.MkdirAll(filepath.Join(dir, "fonts"), 0755))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "fonts", "Bold.ttf"), []byte("ttf"), 0644))
+	over := container.NewThemeOverride(widget.NewLabel("Hi"), theme.DefaultTheme())
+	meta := map[fyne.CanvasObject]map[string]string{over: {"data": `{"Fonts": {"bold": "fonts/Bold.ttf"}}`}}
+	ctx := &testContext{meta: meta, root: storage.NewFileURI(dir)}
+
+	assert.Equal(t, []string{"fonts/Bold.ttf"}, FontsUsed(ctx.meta))
+	code := GoStringFor(over, ctx, map[string]string{})
+	assert.Contains(t, code, "return &fontTheme{Theme: th, bold: resourceBoldTtf}")
+	assert.NotContains(t, code, "fonts/Bold.ttf")
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeObject(over, ctx, &buf))
+	paths, err := FontsUsedInJSON(&buf)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"fonts/Bold.ttf"}, paths)
+
+	var res strings.Builder
+	require.Nil(t, ExportResourcesGo(ctx, paths, &res))
+	assert.Contains(t, res.String(), "var resourceBoldTtf = &fyne.StaticResource{")
+	assert.Contains(t, res.String(), "type fontTheme struct {")
+
+	res.Reset()
+	require.Nil(t, ExportResourcesGo(ctx, nil, &res))
+	assert.NotContains(t, res.String(), "fontTheme")
+}
+
+func TestEncodeDecodeLine(t *testing.T) {
+	l := canvas.NewLine(color.NRGBA{R: 0xff, A: 0xff})
+	l.StrokeWidth = 3
+
+	var buf bytes.Buffer
+	err := EncodeObject(l, newTestContext(nil), &buf)
+	assert.Nil(t, err)
+
+	obj, _, err := DecodeObject(&buf, newTestContext(nil))
+	assert.Nil(t, err)
+	line, ok := obj.(*canvas.Line)
+	require.True(t, ok)
+	assert.Equal(t, float32(3), line.StrokeWidth)
+	assert.Equal(t, &color.NRGBA{R: 0xff, A: 0xff}, line.StrokeColor)
+}
+
+func TestEncodeDecodeRaster(t *testing.T) {
+	test.NewApp()
+	guidefs.InitOnce()
+	r := guidefs.Graphics["*canvas.Raster"].Create(newTestContext(nil)).(*canvas.Raster)
+	r.Translucency = 0.5
+	meta := map[fyne.CanvasObject]map[string]string{r: {"name": "plot", "pixels": "g.plotPixels", "minWidth": "100"}}
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeObject(r, newTestContext(meta), &buf))
+	ctx := newTestContext(nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	require.Nil(t, err)
+	raster, ok := obj.(*canvas.Raster)
+	require.True(t, ok)
+	assert.Equal(t, 0.5, raster.Translucency)
+	assert.Equal(t, "g.plotPixels", ctx.meta[raster]["pixels"])
+	assert.Equal(t, float32(100), raster.MinSize().Width)
+
+	defs := map[string]string{}
+	assert.Equal(t, "g.plot", GoStringFor(raster, ctx, defs))
+	assert.Contains(t, defs["plot"], "canvas.NewRasterWithPixels(g.plotPixels)")
+	assert.Contains(t, defs["plot"], "r.SetMinSize(fyne.NewSize(100, 64))")
+	assert.Equal(t, []string{"image/color"}, HandlerStubImports(raster, ctx, nil))
+
+	var stubs strings.Builder
+	count, err := ExportHandlerStubs(raster, ctx, "main", nil, &stubs)
+	require.Nil(t, err)
+	assert.Equal(t, 1, count)
+	assert.Contains(t, stubs.String(), "func (g *gui) plotPixels(x, y, w, h int) color.Color {\n\treturn color.Transparent\n}")
+
+	delete(ctx.meta[raster], "pixels")
+	assert.Contains(t, GoStringFor(raster, ctx, map[string]string{}), "return color.Transparent")
+}
+
+func TestEncodeDecodeThemeColor(t *testing.T) {
+	test.NewApp()
+	r := canvas.NewRectangle(color.Black)
+	props := map[string]string{"color.FillColor": string(theme.ColorNamePrimary)}
+	meta := map[fyne.CanvasObject]map[string]string{r: props}
+
+	var buf bytes.Buffer
+	err := EncodeObject(r, newTestContext(meta), &buf)
+	assert.Nil(t, err)
+
+	ctx := newTestContext(nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	assert.Nil(t, err)
+	rect, ok := obj.(*canvas.Rectangle)
+	require.True(t, ok)
+	assert.Equal(t, string(theme.ColorNamePrimary), ctx.meta[rect]["color.FillColor"])
+	assert.Equal(t, color.NRGBAModel.Convert(theme.Color(theme.ColorNamePrimary)),
+		color.NRGBAModel.Convert(rect.FillColor))
+	assert.Contains(t, GoStringFor(rect, ctx, map[string]string{}), "FillColor:theme.Color(theme.ColorNamePrimary)")
+}
+
+func TestThemeColorVariant(t *testing.T) {
+	test.NewApp()
+	r := canvas.NewRectangle(color.Black)
+	meta := map[fyne.CanvasObject]map[string]string{r: {"color.FillColor": string(theme.ColorNameBackground)}}
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeObject(r, newTestContext(meta), &buf))
+	ctx := newTestContext(nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	require.Nil(t, err)
+	rect := obj.(*canvas.Rectangle)
+
+	for _, v := range []fyne.ThemeVariant{theme.VariantLight, theme.VariantDark} {
+		ctx.th = guidefs.NewVariantTheme(theme.DefaultTheme(), v)
+		assert.Equal(t, color.NRGBAModel.Convert(theme.DefaultTheme().Color(theme.ColorNameBackground, v)),
+			color.NRGBAModel.Convert(rect.FillColor))
+	}
+}
+
+func TestEncodeDecodeEntryValidation(t *testing.T) {
+	test.NewApp()
+	e := widget.NewEntry()
+	props := map[string]string{"validation.required": "true", "validation.regexp": "^[a-z]+$"}
+	meta := map[fyne.CanvasObject]map[string]string{e: props}
+
+	var buf bytes.Buffer
+	err := EncodeObject(e, newTestContext(meta), &buf)
+	assert.Nil(t, err)
+
+	ctx := newTestContext(nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	assert.Nil(t, err)
+	entry, ok := obj.(*widget.Entry)
+	require.True(t, ok)
+	require.NotNil(t, entry.Validator)
+	assert.NotNil(t, entry.Validator(""))
+	assert.NotNil(t, entry.Validator("ABC"))
+	assert.Nil(t, entry.Validator("abc"))
+
+	code := GoStringFor(entry, ctx, map[string]string{})
+	assert.Contains(t, code, "Validator: func(s string) error")
+	assert.Contains(t, code, `validation.NewRegexp("^[a-z]+$"`)
+}
+
+func TestEncodeDecodeCustomLayout(t *testing.T) {
+	root := storage.NewFileURI(t.TempDir())
+	err := guidefs.LoadLayouts(newProjectTestContext(root, nil), strings.NewReader(`[{"Name": "Columns", "Type": "columns",
+		"Constructor": "newColumns({{count}})", "Params": [{"Name": "count", "Type": "int", "Default": "2"}],
+		"Preview": "HBox"}]`))
+	require.Nil(t, err)
+
+	l := widget.NewLabel("Hi")
+	c := container.NewHBox(l)
+	meta := map[fyne.CanvasObject]map[string]string{c: {"layout": "Columns", "layout.count": "3"}}
+
+	var buf bytes.Buffer
+	err = EncodeObject(c, newProjectTestContext(root, meta), &buf)
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), `"Layout": "Columns"`)
+
+	ctx := newProjectTestContext(root, nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	assert.Nil(t, err)
+	cont, ok := obj.(*fyne.Container)
+	require.True(t, ok)
+	assert.Equal(t, "Columns", ctx.meta[cont]["layout"])
+	assert.Equal(t, "3", ctx.meta[cont]["layout.count"])
+	assert.Contains(t, GoStringFor(cont, ctx, map[string]string{}), "container.New(newColumns(3), ")
+}
+
+func TestEncodeDecodeFreeLayout(t *testing.T) {
+	b := widget.NewButton("Tap", nil)
+	r := canvas.NewRectangle(color.Black)
+	c := container.NewStack(b, r)
+	ctx := newTestContext(map[fyne.CanvasObject]map[string]string{c: {"layout": "Free", "snap_grid": "10"}})
+	guidefs.SetFreeBounds(b, ctx, fyne.NewPos(10, 20), fyne.NewSize(80, 30))
+	guidefs.SetFreeBounds(r, ctx, fyne.NewPos(100, 0), fyne.NewSize(40.5, 40))
+	assert.Equal(t, float32(10), guidefs.SnapGrid(c, ctx))
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeObject(c, ctx, &buf))
+	assert.Contains(t, buf.String(), `"Layout": "Free"`)
+
+	ctx = newTestContext(nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	require.Nil(t, err)
+	cont := obj.(*fyne.Container)
+	pos, size := guidefs.FreeBounds(cont.Objects[1], ctx)
+	assert.Equal(t, fyne.NewPos(100, 0), pos)
+	assert.Equal(t, fyne.NewSize(40.5, 40), size)
+	assert.Equal(t, fyne.NewSize(0, 0), cont.MinSize()) // matches container.NewWithoutLayout
+
+	code := GoStringFor(cont, ctx, map[string]string{})
+	assert.Contains(t, code, "free := container.NewWithoutLayout(")
+	assert.Contains(t, code, "free.Objects[0].Move(fyne.NewPos(10, 20))")
+	assert.Contains(t, code, "free.Objects[1].Resize(fyne.NewSize(40.5, 40))")
+}
+
+func TestEncodeDecodeCardContent(t *testing.T) {
+	c := widget.NewCard("Title", "Sub", widget.NewButton("Tap", nil))
+	meta := map[fyne.CanvasObject]map[string]string{c: {"name": "myCard"}}
+
+	var buf bytes.Buffer
+	err := EncodeObject(c, newTestContext(meta), &buf)
+	assert.Nil(t, err)
+
+	ctx := newTestContext(nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	assert.Nil(t, err)
+	card, ok := obj.(*widget.Card)
+	require.True(t, ok)
+	assert.Equal(t, "Sub", card.Subtitle)
+	assert.Equal(t, "myCard", ctx.meta[card]["name"])
+	button, ok := card.Content.(*widget.Button)
+	require.True(t, ok)
+	assert.Equal(t, "Tap", button.Text)
+	defs := map[string]string{}
+	assert.Equal(t, "g.myCard", GoStringFor(card, ctx, defs))
+	assert.Contains(t, defs["myCard"], "widget.NewButton(")
+}
+
+func TestEncodeDecodeStyle(t *testing.T) {
+	test.NewApp()
+	root := storage.NewFileURI(t.TempDir())
+	err := guidefs.LoadStyles(newProjectTestContext(root, nil), strings.NewReader(`[{"Name": "heading", "Alignment": "Center",
+		"TextStyle": {"Bold": true}, "Importance": "High"}, {"Name": "danger", "Importance": "Danger",
+		"Color": "Error"}]`))
+	require.Nil(t, err)
+	assert.NotNil(t, guidefs.RegisterStyle(newProjectTestContext(root, nil), guidefs.Style{Name: "bad", Importance: "Loud"}))
+
+	l := widget.NewLabel("Title")
+	r := canvas.NewRectangle(color.Black)
+	c := container.NewVBox(l, r)
+	meta := map[fyne.CanvasObject]map[string]string{l: {"style": "heading"}, r: {"style": "danger"}}
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeObject(c, newProjectTestContext(root, meta), &buf))
+	ctx := newProjectTestContext(root, nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	require.Nil(t, err)
+	objs := obj.(*fyne.Container).Objects
+	label := objs[0].(*widget.Label)
+	assert.Equal(t, "heading", ctx.meta[label]["style"])
+	assert.Equal(t, fyne.TextAlignCenter, label.Alignment)
+	assert.True(t, label.TextStyle.Bold)
+	assert.Equal(t, widget.HighImportance, label.Importance)
+	assert.Equal(t, string(theme.ColorNameError), ctx.meta[objs[1]]["color.FillColor"])
+
+	code := GoStringFor(label, ctx, map[string]string{})
+	assert.Contains(t, code, "Alignment: 1")
+	assert.Contains(t, code, "Importance: 1")
+	assert.Contains(t, GoStringFor(objs[1], ctx, map[string]string{}), "FillColor:theme.Color(theme.ColorNameError)")
+}
+
+func TestResetStyle(t *testing.T) {
+	test.NewApp()
+	guidefs.InitOnce()
+	ctx := newProjectTestContext(storage.NewFileURI(t.TempDir()), nil)
+	require.Nil(t, guidefs.LoadStyles(ctx, strings.NewReader(`[{"Name": "heading", "Alignment": "Center",
+		"Importance": "High"}]`)))
+	prev := guidefs.Styles(ctx)
+
+	l := widget.NewLabel("Title")
+	ctx.meta[l] = map[string]string{"style": "heading"}
+	assert.True(t, guidefs.ApplyStyle(l, ctx))
+	assert.Equal(t, widget.HighImportance, l.Importance)
+
+	require.Nil(t, guidefs.RegisterStyle(ctx, guidefs.Style{Name: "heading", Alignment: "Center"}))
+	assert.True(t, guidefs.ResetStyle(l, prev, ctx))
+	guidefs.ApplyStyle(l, ctx)
+	assert.Equal(t, widget.MediumImportance, l.Importance)
+	assert.Equal(t, fyne.TextAlignCenter, l.Alignment)
+}
+
+func TestEncodeDecodeStrings(t *testing.T) {
+	require.Nil(t, guidefs.SetStrings(newTestContext(nil),
+		map[string]string{"welcome.title": "Welcome", "unused": "Nobody"}))
+	defer guidefs.SetStrings(newTestContext(nil), nil)
+	assert.NotNil(t, guidefs.SetStrings(newTestContext(nil), map[string]string{"a.b": "1", "a-b": "2"}))
+
+	l := widget.NewLabel("Old text")
+	e := widget.NewEntry()
+	c := container.NewVBox(l, e)
+	meta := map[fyne.CanvasObject]map[string]string{l: {"string.Text": "welcome.title"}}
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeObject(c, newTestContext(meta), &buf))
+	used, err := StringsUsedInJSON(bytes.NewReader(buf.Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, []string{"welcome.title"}, used)
+
+	ctx := newTestContext(nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	require.Nil(t, err)
+	label := obj.(*fyne.Container).Objects[0].(*widget.Label)
+	assert.Equal(t, "Welcome", label.Text)
+	assert.Equal(t, []string{"welcome.title"}, StringsUsed(ctx.meta))
+	assert.Equal(t, "widget.NewLabel(strWelcomeTitle)", GoStringFor(label, ctx, map[string]string{}))
+
+	var code strings.Builder
+	require.Nil(t, ExportStringsGo(ctx, &code))
+	assert.Contains(t, code.String(), "strWelcomeTitle = \"Welcome\"")
+	assert.Contains(t, code.String(), "strUnused       = \"Nobody\"")
+}
+
+func TestProjectRegistries(t *testing.T) {
+	a := &testContext{meta: map[fyne.CanvasObject]map[string]string{}, root: storage.NewFileURI("/projects/a")}
+	b := &testContext{meta: map[fyne.CanvasObject]map[string]string{}, root: storage.NewFileURI("/projects/b")}
+	require.Nil(t, guidefs.SetStrings(a, map[string]string{"title": "A"}))
+	require.Nil(t, guidefs.RegisterStyle(a, guidefs.Style{Name: "heading", Alignment: "Center"}))
+	require.Nil(t, guidefs.RegisterLayout(a, guidefs.CustomLayout{Name: "Columns", Type: "columns"}))
+
+	text, ok := guidefs.StringValue(a, "title")
+	assert.True(t, ok)
+	assert.Equal(t, "A", text)
+	_, ok = guidefs.StringValue(b, "title")
+	assert.False(t, ok)
+	assert.Len(t, guidefs.Styles(a), 1)
+	assert.Empty(t, guidefs.Styles(b))
+	assert.True(t, guidefs.IsCustomLayout(a, "Columns"))
+	assert.False(t, guidefs.IsCustomLayout(b, "Columns"))
+	assert.Contains(t, guidefs.LayoutNames(a), "Columns")
+	assert.NotContains(t, guidefs.LayoutNames(b), "Columns")
+}
+
+func TestEventsGoString(t *testing.T) {
+	e := widget.NewEntry()
+	meta := map[fyne.CanvasObject]map[string]string{e: {"OnSubmitted": "g.submit"}}
+	ctx := newTestContext(meta)
+
+	code := GoStringFor(e, ctx, map[string]string{})
+	assert.True(t, strings.HasPrefix(code, "func() *widget.Entry {"))
+	assert.Contains(t, code, "w.OnSubmitted = g.submit\n")
+
+	var buf strings.Builder
+	count, err := ExportHandlerStubs(e, ctx, "main", nil, &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	assert.Contains(t, buf.String(), "func (g *gui) submit(v string) {\n}")
+
+	count, _ = ExportHandlerStubs(e, ctx, "main", []string{"submit"}, &buf)
+	assert.Zero(t, count)
+}
+
+func TestEventImports(t *testing.T) {
+	test.NewApp()
+	date := widget.NewDateEntry()
+	ctx := newTestContext(map[fyne.CanvasObject]map[string]string{date: {"OnChanged": "g.dateChanged"}})
+	c := container.NewVBox(date)
+
+	var buf strings.Builder
+	require.Nil(t, ExportGo(c, ctx, "main", &buf))
+	assert.Contains(t, buf.String(), "\t\"time\"\n")
+	assert.Equal(t, []string{"time"}, HandlerStubImports(c, ctx, nil))
+	assert.Empty(t, HandlerStubImports(c, ctx, []string{"dateChanged"}))
+}
+
+func TestEncodeDecodeToolbarItems(t *testing.T) {
+	guidefs.InitOnce()
+	bar := widget.NewToolbar(
+		widget.NewToolbarAction(theme.DocumentSaveIcon(), nil),
+		widget.NewToolbarSeparator(),
+		widget.NewToolbarAction(theme.HelpIcon(), nil))
+	meta := map[fyne.CanvasObject]map[string]string{bar: {
+		"name":         "tools",
+		"item0.action": "g.save",
+		"item0.name":   "saveAction",
+		"item2.action": "func() {\nfmt.Println(\"help\")\n}",
+	}}
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeObject(bar, newTestContext(meta), &buf))
+	ctx := newTestContext(nil)
+	obj, _, err := DecodeObject(&buf, ctx)
+	require.Nil(t, err)
+	decoded, ok := obj.(*widget.Toolbar)
+	require.True(t, ok)
+	assert.Len(t, decoded.Items, 3)
+	assert.Equal(t, meta[bar], ctx.meta[decoded])
+
+	var code strings.Builder
+	require.Nil(t, ExportGo(decoded, ctx, "main", &code))
+	assert.Contains(t, code.String(), "saveAction *widget.ToolbarAction")
+	assert.Contains(t, code.String(), "g.saveAction = widget.NewToolbarAction(theme.DocumentSaveIcon(), g.save)")
+	assert.Contains(t, code.String(), "widget.NewToolbarAction(theme.HelpIcon(), func() {")
+
+	code.Reset()
+	count, _ := ExportHandlerStubs(decoded, ctx, "main", nil, &code)
+	assert.Equal(t, 1, count)
+	assert.Contains(t, code.String(), "func (g *gui) save() {\n}")
+}
+
+func TestIconReverse(t *testing.T) {
+	guidefs.InitOnce()
+
+	assert.Equal(t, len(guidefs.Icons), len(guidefs.IconReverse))
+	for _, name := range guidefs.IconNames {
+		assert.Equal(t, name, guidefs.IconName(guidefs.Icons[name]))
+		assert.NotEmpty(t, guidefs.IconCategory(name))
+	}
+}
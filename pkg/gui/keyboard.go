@@ -0,0 +1,108 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// InitialFocus returns the variable name of the widget to focus when a design is shown, from the metadata of
+// the root of a design. Designs that stored a list of names focus the first.
+func InitialFocus(props map[string]string) string {
+	name, _, _ := strings.Cut(props[DesignFocus], ",")
+	return strings.TrimSpace(name)
+}
+
+// Shortcuts returns the keyboard shortcuts of a design, such as "Ctrl+S", mapped to the handler they call.
+func Shortcuts(props map[string]string) map[string]string {
+	keys := make(map[string]string)
+	for k, v := range props {
+		if strings.HasPrefix(k, DesignShortcutPrefix) && v != "" {
+			keys[k[len(DesignShortcutPrefix):]] = v
+		}
+	}
+	return keys
+}
+
+// hasWindowSetup returns true if the design has shortcuts or an initial focus that the window showing it must set up.
+func hasWindowSetup(obj fyne.CanvasObject, d DefyneContext) bool {
+	return initialFocus(obj, d) != "" || len(Shortcuts(d.Metadata()[obj])) > 0
+}
+
+// initialFocus returns the widget to focus when a design is shown, or "" if it is not a focusable widget in it.
+func initialFocus(obj fyne.CanvasObject, d DefyneContext) string {
+	name := InitialFocus(d.Metadata()[obj])
+	if name == "" {
+		return ""
+	}
+
+	found := false
+	var search func(o fyne.CanvasObject)
+	search = func(o fyne.CanvasObject) {
+		if _, ok := o.(fyne.Focusable); ok && d.Metadata()[o]["name"] == name {
+			found = true
+		}
+		for _, child := range childObjects(o) {
+			search(child)
+		}
+	}
+	search(obj)
+
+	if !found {
+		fyne.LogError("Skipping unknown widget "+name+" for initial focus", nil)
+		return ""
+	}
+	return name
+}
+
+// keyboardHandlers adds the handler methods that the shortcuts of a design call to handlers.
+func keyboardHandlers(props map[string]string, handlers map[string]string) {
+	for _, name := range Shortcuts(props) {
+		handlers[name] = "()"
+	}
+}
+
+// keyboardCode returns the setupWindow method that adds the shortcuts and initial focus of a design to a window.
+// Tab moves the focus in the order of the layout, as Fyne has no way to set a different order.
+// Dialog designs also get a removeShortcuts method, to call when the dialog is closed.
+func keyboardCode(obj fyne.CanvasObject, d DefyneContext, receiver string) string {
+	if !hasWindowSetup(obj, d) {
+		return ""
+	}
+
+	props := d.Metadata()[obj]
+	shortcuts := Shortcuts(props)
+	keys := make([]string, 0, len(shortcuts))
+	for k := range shortcuts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	str := &strings.Builder{}
+	remove := &strings.Builder{}
+	str.WriteString("\n// setupWindow adds the keyboard shortcuts and initial focus of the design to the window showing it.\n")
+	fmt.Fprintf(str, "func (g *%s) setupWindow(w fyne.Window) {\n", receiver)
+	for _, k := range keys {
+		sh, key, err := parseShortcut(k)
+		if err != nil || sh == nil {
+			fyne.LogError("Skipping shortcut "+k, err)
+			continue
+		}
+		fmt.Fprintf(str, "\tw.Canvas().AddShortcut(%s, func(fyne.Shortcut) {\n\t\tg.%s()\n\t})\n",
+			shortcutGoString(sh, key), shortcuts[k])
+		fmt.Fprintf(remove, "\tw.Canvas().RemoveShortcut(%s)\n", shortcutGoString(sh, key))
+	}
+
+	if name := initialFocus(obj, d); name != "" {
+		fmt.Fprintf(str, "\tw.Canvas().Focus(g.%s)\n", name)
+	}
+	str.WriteString("}\n")
+
+	if props[DesignKind] == DesignDialog && remove.Len() > 0 {
+		str.WriteString("\n// removeShortcuts removes the keyboard shortcuts of the design from the window that showed it.\n")
+		fmt.Fprintf(str, "func (g *%s) removeShortcuts(w fyne.Window) {\n%s}\n", receiver, remove.String())
+	}
+	return str.String()
+}
@@ -0,0 +1,48 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportKeyboardSetup(t *testing.T) {
+	name, email := widget.NewEntry(), widget.NewEntry()
+	c := container.NewVBox(name, email)
+	meta := map[fyne.CanvasObject]map[string]string{
+		c:     {DesignFocus: "email", DesignShortcutPrefix + "Ctrl+S": "save"},
+		name:  {"name": "name"},
+		email: {"name": "email"},
+	}
+	assert.Equal(t, "email", InitialFocus(meta[c]))
+	assert.Equal(t, map[string]string{"Ctrl+S": "save"}, Shortcuts(meta[c]))
+
+	var code strings.Builder
+	require.Nil(t, ExportGo(c, newTestContext(meta), "main", &code))
+	assert.Contains(t, code.String(), "func (g *gui) setupWindow(w fyne.Window) {")
+	assert.Contains(t, code.String(), "w.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyS, "+
+		"Modifier: fyne.KeyModifierControl}, func(fyne.Shortcut) {")
+	assert.Contains(t, code.String(), "\tw.Canvas().Focus(g.email)\n")
+	assert.Contains(t, code.String(), `"fyne.io/fyne/v2/driver/desktop"`)
+
+	meta[c][DesignFocus] = "missing"
+	meta[c][DesignKind] = DesignDialog
+	code.Reset()
+	require.Nil(t, ExportGo(c, newTestContext(meta), "main", &code))
+	assert.NotContains(t, code.String(), "Focus(")
+	assert.Equal(t, "name", InitialFocus(map[string]string{DesignFocus: "name, email"}))
+	assert.Contains(t, code.String(), "\td.Show()\n\tg.setupWindow(parent)\n")
+	assert.Contains(t, code.String(), "g.removeShortcuts(parent)")
+	assert.Contains(t, code.String(), "func (g *gui) removeShortcuts(w fyne.Window) {")
+
+	code.Reset()
+	count, _ := ExportHandlerStubs(c, newTestContext(meta), "main", nil, &code)
+	assert.Equal(t, 1, count)
+	assert.Contains(t, code.String(), "func (g *gui) save() {\n}")
+}
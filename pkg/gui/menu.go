@@ -0,0 +1,306 @@
+package gui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+// MenuDesign describes the main menu of a window and the menu shown in the system tray.
+type MenuDesign struct {
+	Main []*MenuDesignMenu `json:",omitempty"`
+	Tray []*MenuDesignItem `json:",omitempty"`
+}
+
+// MenuDesignMenu is a top level menu of the main menu, such as "File".
+type MenuDesignMenu struct {
+	Label string
+	Items []*MenuDesignItem `json:",omitempty"`
+}
+
+// MenuDesignItem is an entry in a menu. Items with children show a sub-menu and separators ignore other fields.
+// The Action is the name of the handler method to call, Icon the name of a theme icon and Shortcut a key
+// combination such as "Ctrl+Shift+S".
+type MenuDesignItem struct {
+	Label     string            `json:",omitempty"`
+	Icon      string            `json:",omitempty"`
+	Shortcut  string            `json:",omitempty"`
+	Action    string            `json:",omitempty"`
+	Checked   bool              `json:",omitempty"`
+	Disabled  bool              `json:",omitempty"`
+	Separator bool              `json:",omitempty"`
+	Items     []*MenuDesignItem `json:",omitempty"`
+}
+
+var shortcutModifiers = map[string]fyne.KeyModifier{
+	"Alt":      fyne.KeyModifierAlt,
+	"Ctrl":     fyne.KeyModifierControl,
+	"Shift":    fyne.KeyModifierShift,
+	"Shortcut": fyne.KeyModifierShortcutDefault,
+	"Super":    fyne.KeyModifierSuper,
+}
+
+var shortcutModifierNames = map[string]string{
+	"Alt":      "fyne.KeyModifierAlt",
+	"Ctrl":     "fyne.KeyModifierControl",
+	"Shift":    "fyne.KeyModifierShift",
+	"Shortcut": "fyne.KeyModifierShortcutDefault",
+	"Super":    "fyne.KeyModifierSuper",
+}
+
+// shortcutKeys lists the keys, other than letters, digits and function keys, that a shortcut can use
+// by the name of their constant without the "Key" prefix.
+var shortcutKeys = map[string]fyne.KeyName{
+	"Backspace": fyne.KeyBackspace,
+	"Comma":     fyne.KeyComma,
+	"Delete":    fyne.KeyDelete,
+	"Down":      fyne.KeyDown,
+	"End":       fyne.KeyEnd,
+	"Escape":    fyne.KeyEscape,
+	"Home":      fyne.KeyHome,
+	"Insert":    fyne.KeyInsert,
+	"Left":      fyne.KeyLeft,
+	"Minus":     fyne.KeyMinus,
+	"PageDown":  fyne.KeyPageDown,
+	"PageUp":    fyne.KeyPageUp,
+	"Period":    fyne.KeyPeriod,
+	"Plus":      fyne.KeyPlus,
+	"Return":    fyne.KeyReturn,
+	"Right":     fyne.KeyRight,
+	"Slash":     fyne.KeySlash,
+	"Space":     fyne.KeySpace,
+	"Tab":       fyne.KeyTab,
+	"Up":        fyne.KeyUp,
+}
+
+// DecodeMenu reads a menu design from the JSON `Reader`.
+func DecodeMenu(r io.Reader) (*MenuDesign, error) {
+	d := &MenuDesign{}
+	err := json.NewDecoder(r).Decode(d)
+	if err == io.EOF {
+		return d, nil
+	}
+	return d, err
+}
+
+// EncodeMenu writes the menu design as JSON to the `Writer`.
+func EncodeMenu(d *MenuDesign, w io.Writer) error {
+	tx := json.NewEncoder(w)
+	tx.SetIndent("", "  ")
+	return tx.Encode(d)
+}
+
+// ParseShortcut returns the keyboard shortcut described by text such as "Ctrl+S", or nil if it is empty.
+func ParseShortcut(s string) (*desktop.CustomShortcut, error) {
+	sh, _, err := parseShortcut(s)
+	return sh, err
+}
+
+// parseShortcut returns the shortcut described by the text and the name of the key constant, such as "S".
+func parseShortcut(s string) (*desktop.CustomShortcut, string, error) {
+	if s == "" {
+		return nil, "", nil
+	}
+
+	parts := strings.Split(s, "+")
+	sh := &desktop.CustomShortcut{}
+	for _, mod := range parts[:len(parts)-1] {
+		m, ok := shortcutModifiers[strings.TrimSpace(mod)]
+		if !ok {
+			return nil, "", errors.New("unknown modifier " + mod + ", use Alt, Ctrl, Shift, Super or Shortcut")
+		}
+		sh.Modifier |= m
+	}
+
+	key := strings.TrimSpace(parts[len(parts)-1])
+	if len(key) == 1 {
+		key = strings.ToUpper(key)
+		if (key[0] < 'A' || key[0] > 'Z') && (key[0] < '0' || key[0] > '9') {
+			return nil, "", errors.New("unknown key " + key)
+		}
+		sh.KeyName = fyne.KeyName(key)
+		return sh, key, nil
+	}
+	if key != "" && (key[0] == 'F' || key[0] == 'f') {
+		if n, err := strconv.Atoi(key[1:]); err == nil && n >= 1 && n <= 12 {
+			sh.KeyName = fyne.KeyName("F" + key[1:])
+			return sh, "F" + key[1:], nil
+		}
+	}
+	for n, k := range shortcutKeys {
+		if strings.EqualFold(n, key) {
+			sh.KeyName = k
+			return sh, n, nil
+		}
+	}
+	return nil, "", errors.New("unknown key " + key)
+}
+
+// BuildMenu creates the main menu and tray menu of a design, calling the action function with the name
+// of the handler when an item is tapped.
+func BuildMenu(d *MenuDesign, action func(string)) (*fyne.MainMenu, *fyne.Menu) {
+	guidefs.InitOnce()
+
+	menus := make([]*fyne.Menu, len(d.Main))
+	for i, m := range d.Main {
+		menus[i] = fyne.NewMenu(m.Label, buildMenuItems(m.Items, action)...)
+	}
+
+	var tray *fyne.Menu
+	if len(d.Tray) > 0 {
+		tray = fyne.NewMenu("", buildMenuItems(d.Tray, action)...)
+	}
+	return fyne.NewMainMenu(menus...), tray
+}
+
+func buildMenuItems(items []*MenuDesignItem, action func(string)) []*fyne.MenuItem {
+	ret := make([]*fyne.MenuItem, len(items))
+	for i, it := range items {
+		if it.Separator {
+			ret[i] = fyne.NewMenuItemSeparator()
+			continue
+		}
+
+		item := &fyne.MenuItem{Label: it.Label, Icon: guidefs.Icons[it.Icon], Checked: it.Checked, Disabled: it.Disabled}
+		if sh, err := ParseShortcut(it.Shortcut); err == nil && sh != nil {
+			item.Shortcut = sh
+		}
+		if it.Action != "" {
+			name := it.Action
+			item.Action = func() {
+				action(name)
+			}
+		}
+		if len(it.Items) > 0 {
+			item.ChildMenu = fyne.NewMenu("", buildMenuItems(it.Items, action)...)
+		}
+		ret[i] = item
+	}
+	return ret
+}
+
+// ExportMenuGo generates the Go code for a menu design and writes it to the provided file handle.
+// The code adds `makeMenu()` and, if there is a tray menu, `makeTrayMenu()` methods to the type that
+// the design with the same name uses.
+func ExportMenuGo(d *MenuDesign, name string, w io.Writer) error {
+	guidefs.InitOnce()
+
+	pkgs := map[string]bool{}
+	str := &strings.Builder{}
+	fmt.Fprintf(str, "\nfunc (g *%s) makeMenu() *fyne.MainMenu {\n\treturn fyne.NewMainMenu(\n", HandlerReceiver(name))
+	for _, m := range d.Main {
+		fmt.Fprintf(str, "fyne.NewMenu(%s,\n", strconv.Quote(m.Label))
+		writeMenuItems(str, m.Items, pkgs)
+		str.WriteString("),\n")
+	}
+	str.WriteString(")\n}\n")
+
+	if len(d.Tray) > 0 {
+		fmt.Fprintf(str, "\nfunc (g *%s) makeTrayMenu() *fyne.Menu {\n\treturn fyne.NewMenu(\"\",\n", HandlerReceiver(name))
+		writeMenuItems(str, d.Tray, pkgs)
+		str.WriteString(")\n}\n")
+	}
+
+	imports := []string{`"fyne.io/fyne/v2"`}
+	for p := range pkgs {
+		imports = append(imports, `"fyne.io/fyne/v2/`+p+`"`)
+	}
+	sort.Strings(imports[1:])
+	code := "// auto-generated\n// Code generated by GUI builder.\n\npackage main\n\nimport (\n\t" +
+		strings.Join(imports, "\n\t") + "\n)\n" + str.String()
+
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		fyne.LogError("Failed to format menu code", err)
+	} else {
+		code = string(formatted)
+	}
+
+	_, err = w.Write([]byte(code))
+	return err
+}
+
+func writeMenuItems(str *strings.Builder, items []*MenuDesignItem, pkgs map[string]bool) {
+	for _, it := range items {
+		if it.Separator {
+			str.WriteString("fyne.NewMenuItemSeparator(),\n")
+			continue
+		}
+
+		fields := []string{"Label: " + strconv.Quote(it.Label)}
+		if _, ok := guidefs.Icons[it.Icon]; ok {
+			pkgs["theme"] = true
+			fields = append(fields, "Icon: theme."+it.Icon+"()")
+		}
+		if sh, key, err := parseShortcut(it.Shortcut); err == nil && sh != nil {
+			pkgs["driver/desktop"] = true
+			fields = append(fields, "Shortcut: "+shortcutGoString(sh, key))
+		}
+		if it.Action != "" {
+			fields = append(fields, "Action: g."+it.Action)
+		}
+		if it.Checked {
+			fields = append(fields, "Checked: true")
+		}
+		if it.Disabled {
+			fields = append(fields, "Disabled: true")
+		}
+
+		if len(it.Items) == 0 {
+			str.WriteString("&fyne.MenuItem{" + strings.Join(fields, ", ") + "},\n")
+			continue
+		}
+		str.WriteString("&fyne.MenuItem{" + strings.Join(fields, ", ") + ", ChildMenu: fyne.NewMenu(\"\",\n")
+		writeMenuItems(str, it.Items, pkgs)
+		str.WriteString(")},\n")
+	}
+}
+
+func shortcutGoString(sh *desktop.CustomShortcut, key string) string {
+	key = "fyne.Key" + key
+	var mods []string
+	for _, n := range []string{"Shortcut", "Ctrl", "Alt", "Shift", "Super"} {
+		if sh.Modifier&shortcutModifiers[n] != 0 {
+			mods = append(mods, shortcutModifierNames[n])
+		}
+	}
+	if len(mods) == 0 {
+		return "&desktop.CustomShortcut{KeyName: " + key + "}"
+	}
+	return "&desktop.CustomShortcut{KeyName: " + key + ", Modifier: " + strings.Join(mods, " | ") + "}"
+}
+
+// MenuHandlers returns the handler methods that the items of a menu design call, mapped to their parameters, "()".
+func MenuHandlers(d *MenuDesign) map[string]string {
+	handlers := make(map[string]string)
+	var collect func([]*MenuDesignItem)
+	collect = func(items []*MenuDesignItem) {
+		for _, it := range items {
+			if it.Action != "" && !it.Separator {
+				handlers[it.Action] = "()"
+			}
+			collect(it.Items)
+		}
+	}
+	for _, m := range d.Main {
+		collect(m.Items)
+	}
+	collect(d.Tray)
+	return handlers
+}
+
+// ExportMenuHandlerStubs writes empty methods for each handler that the items of a menu design call,
+// unless it is listed in existing. It returns the number of methods written.
+func ExportMenuHandlerStubs(d *MenuDesign, name string, existing []string, w io.Writer) (int, error) {
+	return writeHandlerStubs(MenuHandlers(d), name, existing, w)
+}
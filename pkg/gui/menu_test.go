@@ -0,0 +1,54 @@
+package gui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeMenu(t *testing.T) {
+	d := &MenuDesign{
+		Main: []*MenuDesignMenu{{Label: "File", Items: []*MenuDesignItem{
+			{Label: "Open", Icon: "FolderOpenIcon", Shortcut: "Ctrl+O", Action: "open"},
+			{Separator: true},
+			{Label: "Recent", Items: []*MenuDesignItem{{Label: "None", Disabled: true}}},
+		}}},
+		Tray: []*MenuDesignItem{{Label: "Show", Action: "show", Checked: true}},
+	}
+
+	var buf bytes.Buffer
+	require.Nil(t, EncodeMenu(d, &buf))
+	d2, err := DecodeMenu(&buf)
+	require.Nil(t, err)
+	assert.Equal(t, d, d2)
+
+	var code strings.Builder
+	require.Nil(t, ExportMenuGo(d2, "main", &code))
+	assert.Contains(t, code.String(), "func (g *gui) makeMenu() *fyne.MainMenu {")
+	assert.Contains(t, code.String(), "Icon: theme.FolderOpenIcon(), Shortcut: &desktop.CustomShortcut{KeyName: fyne.KeyO, "+
+		"Modifier: fyne.KeyModifierControl}, Action: g.open}")
+	assert.Contains(t, code.String(), "func (g *gui) makeTrayMenu() *fyne.Menu {")
+
+	code.Reset()
+	count, err := ExportMenuHandlerStubs(d2, "main", []string{"show"}, &code)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "\nfunc (g *gui) open() {\n}\n", code.String())
+
+	handlers := MenuHandlers(d2)
+	assert.Equal(t, map[string]string{"open": "()", "show": "()"}, handlers)
+	assert.Nil(t, CheckHandlers(handlers, map[string]string{"open": "()", "submit": "(v string)"}))
+	assert.NotNil(t, CheckHandlers(handlers, map[string]string{"show": "(v bool)"}))
+
+	sh, err := ParseShortcut("Shortcut+Shift+PageUp")
+	require.Nil(t, err)
+	assert.Equal(t, fyne.KeyPageUp, sh.KeyName)
+	assert.Equal(t, fyne.KeyModifierShortcutDefault|fyne.KeyModifierShift, sh.Modifier)
+	_, err = ParseShortcut("Hyper+S")
+	assert.NotNil(t, err)
+}
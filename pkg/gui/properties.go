@@ -0,0 +1,122 @@
+package gui
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+// EncodeProperties returns the fields of a single object that a design stores, without the objects it contains,
+// in a form that DecodeProperties can apply to the same object later.
+// Properties stored in the metadata, such as the layout of a container, are not included.
+func EncodeProperties(obj fyne.CanvasObject, d DefyneContext) (map[string]interface{}, error) {
+	guidefs.InitOnce()
+
+	switch c := obj.(type) {
+	case *fyne.Container, *container.ThemeOverride:
+		return map[string]interface{}{}, nil
+	case *container.AppTabs:
+		items := make([]interface{}, len(c.Items))
+		for i, item := range c.Items {
+			data := map[string]interface{}{"Text": item.Text}
+			if item.Icon != nil {
+				data["Icon"] = guidefs.IconName(item.Icon)
+			}
+			items[i] = data
+		}
+		return map[string]interface{}{"Items": items, "SelectedIndex": c.SelectedIndex()}, nil
+	case *container.Scroll:
+		return map[string]interface{}{"Direction": c.Direction}, nil
+	case *container.Split:
+		return map[string]interface{}{"Horizontal": c.Horizontal, "Offset": c.Offset}, nil
+	case *widget.Card:
+		return map[string]interface{}{"Title": c.Title, "Subtitle": c.Subtitle}, nil
+	}
+
+	tree, err := EncodeMap(obj, d)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var node struct {
+		Struct map[string]interface{}
+	}
+	err = json.Unmarshal(data, &node)
+	return node.Struct, err
+}
+
+// DecodeProperties applies fields returned by EncodeProperties to an existing object, leaving the objects it contains
+// in place. Properties stored in the metadata of the object are applied as well.
+func DecodeProperties(obj fyne.CanvasObject, m map[string]interface{}, d DefyneContext) error {
+	guidefs.InitOnce()
+	props := d.Metadata()[obj]
+
+	switch c := obj.(type) {
+	case *fyne.Container:
+		if lay, ok := guidefs.LookupLayout(d, props["layout"]); ok {
+			c.Layout = lay.Create(c, d)
+		}
+	case *container.ThemeOverride:
+		data := props["data"]
+		if data == "" {
+			data = "{}"
+		}
+		th, err := guidefs.ThemeFromJSON(data, d)
+		if err != nil {
+			return err
+		}
+		c.Theme = th
+	case *container.AppTabs:
+		items, _ := m["Items"].([]interface{})
+		for i, item := range c.Items {
+			if i >= len(items) {
+				break
+			}
+
+			data := items[i].(map[string]interface{})
+			item.Text, _ = data["Text"].(string)
+			item.Icon = nil
+			if name, ok := data["Icon"].(string); ok {
+				item.Icon = guidefs.Icons[name]
+			}
+		}
+		if index, ok := m["SelectedIndex"].(int); ok && index >= 0 && index < len(c.Items) {
+			c.SelectIndex(index)
+		}
+	case *container.Scroll:
+		c.Direction, _ = m["Direction"].(container.ScrollDirection)
+	case *container.Split:
+		c.Horizontal, _ = m["Horizontal"].(bool)
+		c.Offset, _ = m["Offset"].(float64)
+	case *widget.Card:
+		c.Title, _ = m["Title"].(string)
+		c.Subtitle, _ = m["Subtitle"].(string)
+	default:
+		e := reflect.ValueOf(obj).Elem()
+		for k, v := range m {
+			// decodeFields skips empty values, but they have to replace whatever the object holds now
+			if f := e.FieldByName(k); v == nil && f.IsValid() && f.CanSet() {
+				f.Set(reflect.Zero(f.Type()))
+			}
+		}
+		if err := decodeFields(e, m, d); err != nil {
+			return err
+		}
+
+		obj.Refresh()
+		guidefs.Restore(obj, d)
+		return nil
+	}
+
+	obj.Refresh()
+	return nil
+}
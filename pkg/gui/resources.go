@@ -0,0 +1,127 @@
+package gui
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+// ExportResourcesGo writes Go code that bundles the project images and fonts at the paths, relative to the project
+// root of the context, into variables named as `fyne bundle` names them. Generated designs use these for the images
+// they show and the fonts their themes set.
+func ExportResourcesGo(d DefyneContext, paths []string, w io.Writer) error {
+	sorted := append([]string{}, paths...)
+	sort.Strings(sorted)
+
+	str := &strings.Builder{}
+	str.WriteString("// auto-generated\n// Code generated by GUI builder.\n\npackage main\n")
+	if len(sorted) > 0 {
+		str.WriteString("\nimport \"fyne.io/fyne/v2\"\n")
+	}
+	names := make(map[string]string)
+	fonts := false
+	for _, p := range sorted {
+		name := guidefs.BundleName(p)
+		if other, ok := names[name]; ok {
+			if other == p {
+				continue
+			}
+			return fmt.Errorf("files %s and %s would be bundled as the same variable %s", other, p, name)
+		}
+		names[name] = p
+
+		res := guidefs.ProjectResource(d, p)
+		if res == nil {
+			return fmt.Errorf("failed to load resource %s", p)
+		}
+		fonts = fonts || guidefs.IsFontFile(p)
+		fmt.Fprintf(str, "\nvar %s = &fyne.StaticResource{\n\tStaticName:    %q,\n\tStaticContent: []byte(%q),\n}\n",
+			name, res.Name(), res.Content())
+	}
+	if fonts {
+		str.WriteString(guidefs.FontThemeGo)
+	}
+
+	code := str.String()
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		fyne.LogError("Failed to format resources code", err)
+	} else {
+		code = string(formatted)
+	}
+
+	_, err = w.Write([]byte(code))
+	return err
+}
+
+// ImagesUsed returns the paths of the project images that the objects in a design show.
+func ImagesUsed(meta map[fyne.CanvasObject]map[string]string) []string {
+	used := make(map[string]bool)
+	for obj, props := range meta {
+		if _, ok := obj.(*canvas.Image); ok && props[guidefs.ImageResourceProperty] != "" {
+			used[props[guidefs.ImageResourceProperty]] = true
+		}
+	}
+	return sortedKeys(used)
+}
+
+// ImagesUsedInJSON returns the paths of the project images that the objects of an encoded design show.
+func ImagesUsedInJSON(r io.Reader) ([]string, error) {
+	return propertiesInJSON(r, func(key string) bool {
+		return key == guidefs.ImageResourceProperty
+	})
+}
+
+// FontsUsed returns the paths of the project fonts that the themes in a design set.
+func FontsUsed(meta map[fyne.CanvasObject]map[string]string) []string {
+	used := make(map[string]bool)
+	for obj, props := range meta {
+		if _, ok := obj.(*container.ThemeOverride); ok {
+			for _, p := range guidefs.ThemeFonts(props["data"]) {
+				used[p] = true
+			}
+		}
+	}
+	return sortedKeys(used)
+}
+
+// FontsUsedInJSON returns the paths of the project fonts that the themes of an encoded design set.
+func FontsUsedInJSON(r io.Reader) ([]string, error) {
+	var data interface{}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	var search func(v interface{})
+	search = func(v interface{}) {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			if info, ok := node["Struct"].(map[string]interface{}); ok && node["Type"] == "*container.ThemeOverride" {
+				if th, ok := info["Theme"].(string); ok {
+					for _, p := range guidefs.ThemeFonts(th) {
+						used[p] = true
+					}
+				}
+			}
+			for _, child := range node {
+				search(child)
+			}
+		case []interface{}:
+			for _, child := range node {
+				search(child)
+			}
+		}
+	}
+	search(data)
+	return sortedKeys(used), nil
+}
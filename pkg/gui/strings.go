@@ -0,0 +1,108 @@
+package gui
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/fyne-io/defyne/internal/guidefs"
+)
+
+// ExportStringsGo writes the Go constants for the string table of the project of the context, which generated
+// designs use for any text that is linked to a string key.
+func ExportStringsGo(d DefyneContext, w io.Writer) error {
+	str := &strings.Builder{}
+	str.WriteString("// auto-generated\n// Code generated by GUI builder.\n\npackage main\n")
+
+	keys := guidefs.StringKeys(d)
+	if len(keys) > 0 {
+		str.WriteString("\n// Strings from the project string table.\nconst (\n")
+		for _, k := range keys {
+			text, _ := guidefs.StringValue(d, k)
+			fmt.Fprintf(str, "\t%s = %q\n", guidefs.StringConstName(k), text)
+		}
+		str.WriteString(")\n")
+	}
+
+	code := str.String()
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		fyne.LogError("Failed to format strings code", err)
+	} else {
+		code = string(formatted)
+	}
+
+	_, err = w.Write([]byte(code))
+	return err
+}
+
+// StringsUsed returns the string keys that the objects in a design are linked to.
+func StringsUsed(meta map[fyne.CanvasObject]map[string]string) []string {
+	used := make(map[string]bool)
+	for _, props := range meta {
+		addStringsUsed(props, used)
+	}
+	return sortedKeys(used)
+}
+
+// StringsUsedInJSON returns the string keys that the objects of an encoded design are linked to.
+func StringsUsedInJSON(r io.Reader) ([]string, error) {
+	return propertiesInJSON(r, func(key string) bool {
+		return strings.HasPrefix(key, guidefs.StringPropertyPrefix)
+	})
+}
+
+// propertiesInJSON returns the values of the object properties, in an encoded design, whose keys match.
+func propertiesInJSON(r io.Reader, match func(string) bool) ([]string, error) {
+	var data interface{}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	var search func(v interface{})
+	search = func(v interface{}) {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			for k, child := range node {
+				if props, ok := child.(map[string]interface{}); ok && k == "Properties" {
+					for p, value := range props {
+						if s, ok := value.(string); ok && s != "" && match(p) {
+							used[s] = true
+						}
+					}
+					continue
+				}
+				search(child)
+			}
+		case []interface{}:
+			for _, child := range node {
+				search(child)
+			}
+		}
+	}
+	search(data)
+	return sortedKeys(used), nil
+}
+
+func addStringsUsed(props map[string]string, used map[string]bool) {
+	for k, v := range props {
+		if strings.HasPrefix(k, guidefs.StringPropertyPrefix) && v != "" {
+			used[v] = true
+		}
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -8,5 +8,6 @@ var templates = []template{
 	{name: "Go source", ext: ".go"},
 	{name: "Text file", ext: ".txt"},
 	{name: "User interface", ext: ".gui.json"},
+	{name: "Menus", ext: ".menu.json"},
 	{name: "Empty file", ext: ""},
 }
@@ -30,9 +30,10 @@ func (e *codeEntry) TypedShortcut(shortcut fyne.Shortcut) {
 }
 
 type textEditor struct {
-	uri    fyne.URI
-	entry  *codeEntry
-	edited bool
+	uri       fyne.URI
+	entry     *codeEntry
+	edited    bool
+	onChanged func()
 }
 
 func newTextEditor(u fyne.URI, _ fyne.Window) editor {
@@ -49,7 +50,12 @@ func newTextEditor(u fyne.URI, _ fyne.Window) editor {
 	_ = f.Close()
 
 	text.OnChanged = func(_ string) {
+		if editor.edited {
+			return
+		}
+
 		editor.edited = true
+		editor.notifyChanged()
 	}
 	return editor
 }
@@ -74,4 +80,15 @@ func (t *textEditor) save() {
 	_ = w.Close()
 
 	t.edited = false
+	t.notifyChanged()
+}
+
+func (t *textEditor) setOnChanged(fn func()) {
+	t.onChanged = fn
+}
+
+func (t *textEditor) notifyChanged() {
+	if t.onChanged != nil {
+		t.onChanged()
+	}
 }